@@ -0,0 +1,1734 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.21.12
+// source: catalogue/catalogue.proto
+
+// Read only access to the course catalogue and the articles reporting
+// admissions. Generate the Go code with
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative catalogue/catalogue.proto
+
+package catalogue
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type University struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NameEn     string `protobuf:"bytes,2,opt,name=name_en,json=nameEn,proto3" json:"name_en,omitempty"`
+	City       string `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	IsFromDaad bool   `protobuf:"varint,4,opt,name=is_from_daad,json=isFromDaad,proto3" json:"is_from_daad,omitempty"`
+	IsTu9      bool   `protobuf:"varint,5,opt,name=is_tu9,json=isTu9,proto3" json:"is_tu9,omitempty"`
+	IsU15      bool   `protobuf:"varint,6,opt,name=is_u15,json=isU15,proto3" json:"is_u15,omitempty"`
+	QsRanking  int32  `protobuf:"varint,7,opt,name=qs_ranking,json=qsRanking,proto3" json:"qs_ranking,omitempty"`
+	Link       string `protobuf:"bytes,8,opt,name=link,proto3" json:"link,omitempty"`
+}
+
+func (x *University) Reset() {
+	*x = University{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *University) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*University) ProtoMessage() {}
+
+func (x *University) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use University.ProtoReflect.Descriptor instead.
+func (*University) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *University) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *University) GetNameEn() string {
+	if x != nil {
+		return x.NameEn
+	}
+	return ""
+}
+
+func (x *University) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *University) GetIsFromDaad() bool {
+	if x != nil {
+		return x.IsFromDaad
+	}
+	return false
+}
+
+func (x *University) GetIsTu9() bool {
+	if x != nil {
+		return x.IsTu9
+	}
+	return false
+}
+
+func (x *University) GetIsU15() bool {
+	if x != nil {
+		return x.IsU15
+	}
+	return false
+}
+
+func (x *University) GetQsRanking() int32 {
+	if x != nil {
+		return x.QsRanking
+	}
+	return 0
+}
+
+func (x *University) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+type Course struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                       int32            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UniversityId             int32            `protobuf:"varint,2,opt,name=university_id,json=universityId,proto3" json:"university_id,omitempty"`
+	CourseType               string           `protobuf:"bytes,3,opt,name=course_type,json=courseType,proto3" json:"course_type,omitempty"`
+	NameEn                   string           `protobuf:"bytes,4,opt,name=name_en,json=nameEn,proto3" json:"name_en,omitempty"`
+	NameEnShort              string           `protobuf:"bytes,5,opt,name=name_en_short,json=nameEnShort,proto3" json:"name_en_short,omitempty"`
+	TuitionFees              string           `protobuf:"bytes,6,opt,name=tuition_fees,json=tuitionFees,proto3" json:"tuition_fees,omitempty"`
+	Beginning                string           `protobuf:"bytes,7,opt,name=beginning,proto3" json:"beginning,omitempty"`
+	Subject                  string           `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"`
+	Daadlink                 string           `protobuf:"bytes,9,opt,name=daadlink,proto3" json:"daadlink,omitempty"`
+	IsElearning              bool             `protobuf:"varint,10,opt,name=is_elearning,json=isElearning,proto3" json:"is_elearning,omitempty"`
+	ApplicationDeadline      string           `protobuf:"bytes,11,opt,name=application_deadline,json=applicationDeadline,proto3" json:"application_deadline,omitempty"`
+	IsCompleteOnlinePossible bool             `protobuf:"varint,12,opt,name=is_complete_online_possible,json=isCompleteOnlinePossible,proto3" json:"is_complete_online_possible,omitempty"`
+	ProgrammeDuration        string           `protobuf:"bytes,13,opt,name=programme_duration,json=programmeDuration,proto3" json:"programme_duration,omitempty"`
+	IsFromDaad               bool             `protobuf:"varint,14,opt,name=is_from_daad,json=isFromDaad,proto3" json:"is_from_daad,omitempty"`
+	University               *University      `protobuf:"bytes,15,opt,name=university,proto3" json:"university,omitempty"`
+	Languages                []string         `protobuf:"bytes,16,rep,name=languages,proto3" json:"languages,omitempty"`
+	Articles                 []*CourseArticle `protobuf:"bytes,17,rep,name=articles,proto3" json:"articles,omitempty"`
+}
+
+func (x *Course) Reset() {
+	*x = Course{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Course) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Course) ProtoMessage() {}
+
+func (x *Course) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Course.ProtoReflect.Descriptor instead.
+func (*Course) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Course) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Course) GetUniversityId() int32 {
+	if x != nil {
+		return x.UniversityId
+	}
+	return 0
+}
+
+func (x *Course) GetCourseType() string {
+	if x != nil {
+		return x.CourseType
+	}
+	return ""
+}
+
+func (x *Course) GetNameEn() string {
+	if x != nil {
+		return x.NameEn
+	}
+	return ""
+}
+
+func (x *Course) GetNameEnShort() string {
+	if x != nil {
+		return x.NameEnShort
+	}
+	return ""
+}
+
+func (x *Course) GetTuitionFees() string {
+	if x != nil {
+		return x.TuitionFees
+	}
+	return ""
+}
+
+func (x *Course) GetBeginning() string {
+	if x != nil {
+		return x.Beginning
+	}
+	return ""
+}
+
+func (x *Course) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Course) GetDaadlink() string {
+	if x != nil {
+		return x.Daadlink
+	}
+	return ""
+}
+
+func (x *Course) GetIsElearning() bool {
+	if x != nil {
+		return x.IsElearning
+	}
+	return false
+}
+
+func (x *Course) GetApplicationDeadline() string {
+	if x != nil {
+		return x.ApplicationDeadline
+	}
+	return ""
+}
+
+func (x *Course) GetIsCompleteOnlinePossible() bool {
+	if x != nil {
+		return x.IsCompleteOnlinePossible
+	}
+	return false
+}
+
+func (x *Course) GetProgrammeDuration() string {
+	if x != nil {
+		return x.ProgrammeDuration
+	}
+	return ""
+}
+
+func (x *Course) GetIsFromDaad() bool {
+	if x != nil {
+		return x.IsFromDaad
+	}
+	return false
+}
+
+func (x *Course) GetUniversity() *University {
+	if x != nil {
+		return x.University
+	}
+	return nil
+}
+
+func (x *Course) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Course) GetArticles() []*CourseArticle {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+// CourseArticle is the result an article reports for the enclosing course.
+type CourseArticle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result     string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	IsDecision bool     `protobuf:"varint,2,opt,name=is_decision,json=isDecision,proto3" json:"is_decision,omitempty"`
+	Article    *Article `protobuf:"bytes,3,opt,name=article,proto3" json:"article,omitempty"`
+}
+
+func (x *CourseArticle) Reset() {
+	*x = CourseArticle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CourseArticle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseArticle) ProtoMessage() {}
+
+func (x *CourseArticle) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseArticle.ProtoReflect.Descriptor instead.
+func (*CourseArticle) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CourseArticle) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *CourseArticle) GetIsDecision() bool {
+	if x != nil {
+		return x.IsDecision
+	}
+	return false
+}
+
+func (x *CourseArticle) GetArticle() *Article {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+type Article struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title               string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Author              string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Link                string                 `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"`
+	PublishedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	Source              string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	AuthorBsSchool      string                 `protobuf:"bytes,7,opt,name=author_bs_school,json=authorBsSchool,proto3" json:"author_bs_school,omitempty"`
+	AuthorBsSchoolShort string                 `protobuf:"bytes,8,opt,name=author_bs_school_short,json=authorBsSchoolShort,proto3" json:"author_bs_school_short,omitempty"`
+	AuthorBsDepartment  string                 `protobuf:"bytes,9,opt,name=author_bs_department,json=authorBsDepartment,proto3" json:"author_bs_department,omitempty"`
+	AuthorBsGpa         string                 `protobuf:"bytes,10,opt,name=author_bs_gpa,json=authorBsGpa,proto3" json:"author_bs_gpa,omitempty"`
+	AuthorMsSchool      string                 `protobuf:"bytes,11,opt,name=author_ms_school,json=authorMsSchool,proto3" json:"author_ms_school,omitempty"`
+	AuthorMsSchoolShort string                 `protobuf:"bytes,12,opt,name=author_ms_school_short,json=authorMsSchoolShort,proto3" json:"author_ms_school_short,omitempty"`
+	AuthorMsDepartment  string                 `protobuf:"bytes,13,opt,name=author_ms_department,json=authorMsDepartment,proto3" json:"author_ms_department,omitempty"`
+	AuthorMsGpa         string                 `protobuf:"bytes,14,opt,name=author_ms_gpa,json=authorMsGpa,proto3" json:"author_ms_gpa,omitempty"`
+	AuthorToefl         string                 `protobuf:"bytes,15,opt,name=author_toefl,json=authorToefl,proto3" json:"author_toefl,omitempty"`
+	AuthorIelts         string                 `protobuf:"bytes,16,opt,name=author_ielts,json=authorIelts,proto3" json:"author_ielts,omitempty"`
+	AuthorGre           string                 `protobuf:"bytes,17,opt,name=author_gre,json=authorGre,proto3" json:"author_gre,omitempty"`
+	AuthorGmat          string                 `protobuf:"bytes,18,opt,name=author_gmat,json=authorGmat,proto3" json:"author_gmat,omitempty"`
+	AuthorTestdaf       string                 `protobuf:"bytes,19,opt,name=author_testdaf,json=authorTestdaf,proto3" json:"author_testdaf,omitempty"`
+	AuthorGoethe        string                 `protobuf:"bytes,20,opt,name=author_goethe,json=authorGoethe,proto3" json:"author_goethe,omitempty"`
+	CourseType          string                 `protobuf:"bytes,21,opt,name=course_type,json=courseType,proto3" json:"course_type,omitempty"`
+	Content             string                 `protobuf:"bytes,22,opt,name=content,proto3" json:"content,omitempty"`
+	Courses             []*ArticleCourse       `protobuf:"bytes,23,rep,name=courses,proto3" json:"courses,omitempty"`
+}
+
+func (x *Article) Reset() {
+	*x = Article{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Article) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Article) ProtoMessage() {}
+
+func (x *Article) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Article.ProtoReflect.Descriptor instead.
+func (*Article) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Article) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Article) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Article) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Article) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Article) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *Article) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorBsSchool() string {
+	if x != nil {
+		return x.AuthorBsSchool
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorBsSchoolShort() string {
+	if x != nil {
+		return x.AuthorBsSchoolShort
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorBsDepartment() string {
+	if x != nil {
+		return x.AuthorBsDepartment
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorBsGpa() string {
+	if x != nil {
+		return x.AuthorBsGpa
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorMsSchool() string {
+	if x != nil {
+		return x.AuthorMsSchool
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorMsSchoolShort() string {
+	if x != nil {
+		return x.AuthorMsSchoolShort
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorMsDepartment() string {
+	if x != nil {
+		return x.AuthorMsDepartment
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorMsGpa() string {
+	if x != nil {
+		return x.AuthorMsGpa
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorToefl() string {
+	if x != nil {
+		return x.AuthorToefl
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorIelts() string {
+	if x != nil {
+		return x.AuthorIelts
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorGre() string {
+	if x != nil {
+		return x.AuthorGre
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorGmat() string {
+	if x != nil {
+		return x.AuthorGmat
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorTestdaf() string {
+	if x != nil {
+		return x.AuthorTestdaf
+	}
+	return ""
+}
+
+func (x *Article) GetAuthorGoethe() string {
+	if x != nil {
+		return x.AuthorGoethe
+	}
+	return ""
+}
+
+func (x *Article) GetCourseType() string {
+	if x != nil {
+		return x.CourseType
+	}
+	return ""
+}
+
+func (x *Article) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Article) GetCourses() []*ArticleCourse {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+// ArticleCourse is the result the enclosing article reports for a course.
+type ArticleCourse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result     string  `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	IsDecision bool    `protobuf:"varint,2,opt,name=is_decision,json=isDecision,proto3" json:"is_decision,omitempty"`
+	Course     *Course `protobuf:"bytes,3,opt,name=course,proto3" json:"course,omitempty"`
+}
+
+func (x *ArticleCourse) Reset() {
+	*x = ArticleCourse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArticleCourse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticleCourse) ProtoMessage() {}
+
+func (x *ArticleCourse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticleCourse.ProtoReflect.Descriptor instead.
+func (*ArticleCourse) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ArticleCourse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ArticleCourse) GetIsDecision() bool {
+	if x != nil {
+		return x.IsDecision
+	}
+	return false
+}
+
+func (x *ArticleCourse) GetCourse() *Course {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+// CourseParams are the filters of GET /v1/courses. Paging is ignored when
+// streaming.
+type CourseParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageNumber          int32  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	PageSize            int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SearchTerm          string `protobuf:"bytes,3,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	CourseTypes         string `protobuf:"bytes,4,opt,name=course_types,json=courseTypes,proto3" json:"course_types,omitempty"`
+	Languages           string `protobuf:"bytes,5,opt,name=languages,proto3" json:"languages,omitempty"`
+	Subjects            string `protobuf:"bytes,6,opt,name=subjects,proto3" json:"subjects,omitempty"`
+	Institutions        string `protobuf:"bytes,7,opt,name=institutions,proto3" json:"institutions,omitempty"`
+	IsTu9               bool   `protobuf:"varint,8,opt,name=is_tu9,json=isTu9,proto3" json:"is_tu9,omitempty"`
+	IsU15               bool   `protobuf:"varint,9,opt,name=is_u15,json=isU15,proto3" json:"is_u15,omitempty"`
+	HasArticles         bool   `protobuf:"varint,10,opt,name=has_articles,json=hasArticles,proto3" json:"has_articles,omitempty"`
+	OrderBy             string `protobuf:"bytes,11,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	HideLanguageArticle bool   `protobuf:"varint,12,opt,name=hide_language_article,json=hideLanguageArticle,proto3" json:"hide_language_article,omitempty"`
+}
+
+func (x *CourseParams) Reset() {
+	*x = CourseParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CourseParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseParams) ProtoMessage() {}
+
+func (x *CourseParams) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseParams.ProtoReflect.Descriptor instead.
+func (*CourseParams) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CourseParams) GetPageNumber() int32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *CourseParams) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *CourseParams) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+func (x *CourseParams) GetCourseTypes() string {
+	if x != nil {
+		return x.CourseTypes
+	}
+	return ""
+}
+
+func (x *CourseParams) GetLanguages() string {
+	if x != nil {
+		return x.Languages
+	}
+	return ""
+}
+
+func (x *CourseParams) GetSubjects() string {
+	if x != nil {
+		return x.Subjects
+	}
+	return ""
+}
+
+func (x *CourseParams) GetInstitutions() string {
+	if x != nil {
+		return x.Institutions
+	}
+	return ""
+}
+
+func (x *CourseParams) GetIsTu9() bool {
+	if x != nil {
+		return x.IsTu9
+	}
+	return false
+}
+
+func (x *CourseParams) GetIsU15() bool {
+	if x != nil {
+		return x.IsU15
+	}
+	return false
+}
+
+func (x *CourseParams) GetHasArticles() bool {
+	if x != nil {
+		return x.HasArticles
+	}
+	return false
+}
+
+func (x *CourseParams) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *CourseParams) GetHideLanguageArticle() bool {
+	if x != nil {
+		return x.HideLanguageArticle
+	}
+	return false
+}
+
+// ArticleParams are the filters of GET /v1/articles. Paging is ignored when
+// streaming.
+type ArticleParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageNumber      int32  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	PageSize        int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SearchTerm      string `protobuf:"bytes,3,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	Sources         string `protobuf:"bytes,4,opt,name=sources,proto3" json:"sources,omitempty"`
+	BsSchools       string `protobuf:"bytes,5,opt,name=bs_schools,json=bsSchools,proto3" json:"bs_schools,omitempty"`
+	BsDepartments   string `protobuf:"bytes,6,opt,name=bs_departments,json=bsDepartments,proto3" json:"bs_departments,omitempty"`
+	MsSchools       string `protobuf:"bytes,7,opt,name=ms_schools,json=msSchools,proto3" json:"ms_schools,omitempty"`
+	MsDepartments   string `protobuf:"bytes,8,opt,name=ms_departments,json=msDepartments,proto3" json:"ms_departments,omitempty"`
+	CourseType      string `protobuf:"bytes,9,opt,name=course_type,json=courseType,proto3" json:"course_type,omitempty"`
+	HideApplication bool   `protobuf:"varint,10,opt,name=hide_application,json=hideApplication,proto3" json:"hide_application,omitempty"`
+}
+
+func (x *ArticleParams) Reset() {
+	*x = ArticleParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArticleParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticleParams) ProtoMessage() {}
+
+func (x *ArticleParams) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticleParams.ProtoReflect.Descriptor instead.
+func (*ArticleParams) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ArticleParams) GetPageNumber() int32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *ArticleParams) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ArticleParams) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetSources() string {
+	if x != nil {
+		return x.Sources
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetBsSchools() string {
+	if x != nil {
+		return x.BsSchools
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetBsDepartments() string {
+	if x != nil {
+		return x.BsDepartments
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetMsSchools() string {
+	if x != nil {
+		return x.MsSchools
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetMsDepartments() string {
+	if x != nil {
+		return x.MsDepartments
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetCourseType() string {
+	if x != nil {
+		return x.CourseType
+	}
+	return ""
+}
+
+func (x *ArticleParams) GetHideApplication() bool {
+	if x != nil {
+		return x.HideApplication
+	}
+	return false
+}
+
+// Pagination holds the values of the Pagination header of the REST listings.
+type Pagination struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrentPage int32 `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages  int32 `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	PageSize    int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalCount  int32 `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Pagination) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *Pagination) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetCourseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetCourseRequest) Reset() {
+	*x = GetCourseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseRequest) ProtoMessage() {}
+
+func (x *GetCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseRequest.ProtoReflect.Descriptor instead.
+func (*GetCourseRequest) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCourseRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetArticleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetArticleRequest) Reset() {
+	*x = GetArticleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetArticleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArticleRequest) ProtoMessage() {}
+
+func (x *GetArticleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArticleRequest.ProtoReflect.Descriptor instead.
+func (*GetArticleRequest) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetArticleRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type StreamUniversitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamUniversitiesRequest) Reset() {
+	*x = StreamUniversitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamUniversitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamUniversitiesRequest) ProtoMessage() {}
+
+func (x *StreamUniversitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamUniversitiesRequest.ProtoReflect.Descriptor instead.
+func (*StreamUniversitiesRequest) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{10}
+}
+
+type CoursePage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Courses    []*Course   `protobuf:"bytes,1,rep,name=courses,proto3" json:"courses,omitempty"`
+	Pagination *Pagination `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *CoursePage) Reset() {
+	*x = CoursePage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CoursePage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoursePage) ProtoMessage() {}
+
+func (x *CoursePage) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoursePage.ProtoReflect.Descriptor instead.
+func (*CoursePage) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CoursePage) GetCourses() []*Course {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+func (x *CoursePage) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ArticlePage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Articles   []*Article  `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+	Pagination *Pagination `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *ArticlePage) Reset() {
+	*x = ArticlePage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_catalogue_catalogue_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArticlePage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticlePage) ProtoMessage() {}
+
+func (x *ArticlePage) ProtoReflect() protoreflect.Message {
+	mi := &file_catalogue_catalogue_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticlePage.ProtoReflect.Descriptor instead.
+func (*ArticlePage) Descriptor() ([]byte, []int) {
+	return file_catalogue_catalogue_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ArticlePage) GetArticles() []*Article {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+func (x *ArticlePage) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_catalogue_catalogue_proto protoreflect.FileDescriptor
+
+var file_catalogue_catalogue_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2f, 0x63, 0x61, 0x74, 0x61,
+	0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x67, 0x6f, 0x67,
+	0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65,
+	0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc, 0x01, 0x0a, 0x0a, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79,
+	0x12, 0x20, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x64, 0x61, 0x61, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x44, 0x61,
+	0x61, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x74, 0x75, 0x39, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x54, 0x75, 0x39, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f,
+	0x75, 0x31, 0x35, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x55, 0x31, 0x35,
+	0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x73, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x71, 0x73, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c,
+	0x69, 0x6e, 0x6b, 0x22, 0x9d, 0x05, 0x0a, 0x06, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x75, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x75, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74,
+	0x79, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x12, 0x22, 0x0a,
+	0x0d, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x53, 0x68, 0x6f, 0x72,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x75, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x65, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x75, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x65, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x61, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x61, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x65,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x69, 0x73, 0x45, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x14, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x3d,
+	0x0a, 0x1b, 0x69, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x6f, 0x6e,
+	0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x18, 0x69, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x4f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x6f, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x2d, 0x0a,
+	0x12, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x6d, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x6d, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0c,
+	0x69, 0x73, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x64, 0x61, 0x61, 0x64, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x44, 0x61, 0x61, 0x64, 0x12, 0x42,
+	0x0a, 0x0a, 0x75, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74, 0x79, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63,
+	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18,
+	0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0x41, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63,
+	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72,
+	0x73, 0x65, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39,
+	0x0a, 0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61,
+	0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x52, 0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x22, 0xea, 0x06, 0x0a, 0x07, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x3d, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x28,
+	0x0a, 0x10, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x62, 0x73, 0x5f, 0x73, 0x63, 0x68, 0x6f,
+	0x6f, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x42, 0x73, 0x53, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x12, 0x33, 0x0a, 0x16, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x5f, 0x62, 0x73, 0x5f, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x5f, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x42, 0x73, 0x53, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x12, 0x30, 0x0a,
+	0x14, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x62, 0x73, 0x5f, 0x64, 0x65, 0x70, 0x61, 0x72,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x42, 0x73, 0x44, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x22, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x62, 0x73, 0x5f, 0x67, 0x70, 0x61,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x42, 0x73,
+	0x47, 0x70, 0x61, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
+	0x5f, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x4d, 0x73, 0x53, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x12, 0x33, 0x0a,
+	0x16, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x5f, 0x73, 0x63, 0x68, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x4d, 0x73, 0x53, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x53, 0x68, 0x6f,
+	0x72, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x5f,
+	0x64, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x12, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x4d, 0x73, 0x44, 0x65, 0x70, 0x61, 0x72, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x6d,
+	0x73, 0x5f, 0x67, 0x70, 0x61, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x4d, 0x73, 0x47, 0x70, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x5f, 0x74, 0x6f, 0x65, 0x66, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x54, 0x6f, 0x65, 0x66, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x65, 0x6c, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x65, 0x6c, 0x74, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x67, 0x72, 0x65, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x47, 0x72, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x67, 0x6d, 0x61, 0x74, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x47, 0x6d, 0x61, 0x74, 0x12, 0x25,
+	0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x66,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x54, 0x65,
+	0x73, 0x74, 0x64, 0x61, 0x66, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f,
+	0x67, 0x6f, 0x65, 0x74, 0x68, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x47, 0x6f, 0x65, 0x74, 0x68, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f,
+	0x75, 0x72, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x73,
+	0x18, 0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61,
+	0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x52, 0x07, 0x63,
+	0x6f, 0x75, 0x72, 0x73, 0x65, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0d, 0x41, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x36, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x73,
+	0x65, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x22, 0x8e, 0x03, 0x0a, 0x0c, 0x43, 0x6f,
+	0x75, 0x72, 0x73, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75,
+	0x72, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x69, 0x74,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e,
+	0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73,
+	0x5f, 0x74, 0x75, 0x39, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x54, 0x75,
+	0x39, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x75, 0x31, 0x35, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x69, 0x73, 0x55, 0x31, 0x35, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x61, 0x73, 0x5f,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x68, 0x61, 0x73, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x68, 0x69, 0x64, 0x65, 0x5f, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x68, 0x69, 0x64, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x22, 0xe0, 0x02, 0x0a, 0x0d, 0x41,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x73, 0x5f, 0x73, 0x63, 0x68, 0x6f,
+	0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x73, 0x53, 0x63, 0x68,
+	0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x73, 0x5f, 0x64, 0x65, 0x70, 0x61, 0x72,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x73,
+	0x44, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x73, 0x5f, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6d, 0x73, 0x53, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x73,
+	0x5f, 0x64, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6d, 0x73, 0x44, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x68, 0x69, 0x64, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x68, 0x69,
+	0x64, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8e, 0x01,
+	0x0a, 0x0a, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x22,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a, 0x19, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x8a, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x50,
+	0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79,
+	0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x75, 0x72, 0x73, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x73, 0x12, 0x42, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x8e, 0x01, 0x0a, 0x0b, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x50, 0x61, 0x67,
+	0x65, 0x12, 0x3b, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e,
+	0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x42,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63,
+	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x32, 0x95, 0x05, 0x0a, 0x09, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65,
+	0x12, 0x55, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x12, 0x28, 0x2e,
+	0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
+	0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d,
+	0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x75, 0x72, 0x73, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61,
+	0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x22, 0x2e, 0x67,
+	0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
+	0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x50, 0x61, 0x67, 0x65,
+	0x12, 0x57, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65,
+	0x73, 0x12, 0x24, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x73,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1e, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d,
+	0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x75, 0x72, 0x73, 0x65, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x29, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d,
+	0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63,
+	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x12, 0x5a, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e,
+	0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x23, 0x2e, 0x67, 0x6f, 0x67,
+	0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x5a, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x73, 0x12, 0x25, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1f, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72,
+	0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x30, 0x01, 0x12, 0x6d, 0x0a, 0x12, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x31, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79, 0x2e, 0x63, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x6f, 0x67, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x79,
+	0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e,
+	0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x74, 0x79, 0x30, 0x01, 0x42, 0x13, 0x5a, 0x11, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_catalogue_catalogue_proto_rawDescOnce sync.Once
+	file_catalogue_catalogue_proto_rawDescData = file_catalogue_catalogue_proto_rawDesc
+)
+
+func file_catalogue_catalogue_proto_rawDescGZIP() []byte {
+	file_catalogue_catalogue_proto_rawDescOnce.Do(func() {
+		file_catalogue_catalogue_proto_rawDescData = protoimpl.X.CompressGZIP(file_catalogue_catalogue_proto_rawDescData)
+	})
+	return file_catalogue_catalogue_proto_rawDescData
+}
+
+var file_catalogue_catalogue_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_catalogue_catalogue_proto_goTypes = []interface{}{
+	(*University)(nil),                // 0: gogermany.catalogue.v1.University
+	(*Course)(nil),                    // 1: gogermany.catalogue.v1.Course
+	(*CourseArticle)(nil),             // 2: gogermany.catalogue.v1.CourseArticle
+	(*Article)(nil),                   // 3: gogermany.catalogue.v1.Article
+	(*ArticleCourse)(nil),             // 4: gogermany.catalogue.v1.ArticleCourse
+	(*CourseParams)(nil),              // 5: gogermany.catalogue.v1.CourseParams
+	(*ArticleParams)(nil),             // 6: gogermany.catalogue.v1.ArticleParams
+	(*Pagination)(nil),                // 7: gogermany.catalogue.v1.Pagination
+	(*GetCourseRequest)(nil),          // 8: gogermany.catalogue.v1.GetCourseRequest
+	(*GetArticleRequest)(nil),         // 9: gogermany.catalogue.v1.GetArticleRequest
+	(*StreamUniversitiesRequest)(nil), // 10: gogermany.catalogue.v1.StreamUniversitiesRequest
+	(*CoursePage)(nil),                // 11: gogermany.catalogue.v1.CoursePage
+	(*ArticlePage)(nil),               // 12: gogermany.catalogue.v1.ArticlePage
+	(*timestamppb.Timestamp)(nil),     // 13: google.protobuf.Timestamp
+}
+var file_catalogue_catalogue_proto_depIdxs = []int32{
+	0,  // 0: gogermany.catalogue.v1.Course.university:type_name -> gogermany.catalogue.v1.University
+	2,  // 1: gogermany.catalogue.v1.Course.articles:type_name -> gogermany.catalogue.v1.CourseArticle
+	3,  // 2: gogermany.catalogue.v1.CourseArticle.article:type_name -> gogermany.catalogue.v1.Article
+	13, // 3: gogermany.catalogue.v1.Article.published_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: gogermany.catalogue.v1.Article.courses:type_name -> gogermany.catalogue.v1.ArticleCourse
+	1,  // 5: gogermany.catalogue.v1.ArticleCourse.course:type_name -> gogermany.catalogue.v1.Course
+	1,  // 6: gogermany.catalogue.v1.CoursePage.courses:type_name -> gogermany.catalogue.v1.Course
+	7,  // 7: gogermany.catalogue.v1.CoursePage.pagination:type_name -> gogermany.catalogue.v1.Pagination
+	3,  // 8: gogermany.catalogue.v1.ArticlePage.articles:type_name -> gogermany.catalogue.v1.Article
+	7,  // 9: gogermany.catalogue.v1.ArticlePage.pagination:type_name -> gogermany.catalogue.v1.Pagination
+	8,  // 10: gogermany.catalogue.v1.Catalogue.GetCourse:input_type -> gogermany.catalogue.v1.GetCourseRequest
+	5,  // 11: gogermany.catalogue.v1.Catalogue.ListCourses:input_type -> gogermany.catalogue.v1.CourseParams
+	5,  // 12: gogermany.catalogue.v1.Catalogue.StreamCourses:input_type -> gogermany.catalogue.v1.CourseParams
+	9,  // 13: gogermany.catalogue.v1.Catalogue.GetArticle:input_type -> gogermany.catalogue.v1.GetArticleRequest
+	6,  // 14: gogermany.catalogue.v1.Catalogue.ListArticles:input_type -> gogermany.catalogue.v1.ArticleParams
+	6,  // 15: gogermany.catalogue.v1.Catalogue.StreamArticles:input_type -> gogermany.catalogue.v1.ArticleParams
+	10, // 16: gogermany.catalogue.v1.Catalogue.StreamUniversities:input_type -> gogermany.catalogue.v1.StreamUniversitiesRequest
+	1,  // 17: gogermany.catalogue.v1.Catalogue.GetCourse:output_type -> gogermany.catalogue.v1.Course
+	11, // 18: gogermany.catalogue.v1.Catalogue.ListCourses:output_type -> gogermany.catalogue.v1.CoursePage
+	1,  // 19: gogermany.catalogue.v1.Catalogue.StreamCourses:output_type -> gogermany.catalogue.v1.Course
+	3,  // 20: gogermany.catalogue.v1.Catalogue.GetArticle:output_type -> gogermany.catalogue.v1.Article
+	12, // 21: gogermany.catalogue.v1.Catalogue.ListArticles:output_type -> gogermany.catalogue.v1.ArticlePage
+	3,  // 22: gogermany.catalogue.v1.Catalogue.StreamArticles:output_type -> gogermany.catalogue.v1.Article
+	0,  // 23: gogermany.catalogue.v1.Catalogue.StreamUniversities:output_type -> gogermany.catalogue.v1.University
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_catalogue_catalogue_proto_init() }
+func file_catalogue_catalogue_proto_init() {
+	if File_catalogue_catalogue_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_catalogue_catalogue_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*University); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Course); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CourseArticle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Article); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArticleCourse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CourseParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArticleParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pagination); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCourseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetArticleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamUniversitiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CoursePage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_catalogue_catalogue_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArticlePage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_catalogue_catalogue_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalogue_catalogue_proto_goTypes,
+		DependencyIndexes: file_catalogue_catalogue_proto_depIdxs,
+		MessageInfos:      file_catalogue_catalogue_proto_msgTypes,
+	}.Build()
+	File_catalogue_catalogue_proto = out.File
+	file_catalogue_catalogue_proto_rawDesc = nil
+	file_catalogue_catalogue_proto_goTypes = nil
+	file_catalogue_catalogue_proto_depIdxs = nil
+}
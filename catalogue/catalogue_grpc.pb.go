@@ -0,0 +1,431 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: catalogue/catalogue.proto
+
+// Read only access to the course catalogue and the articles reporting
+// admissions. Generate the Go code with
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative catalogue/catalogue.proto
+
+package catalogue
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Catalogue_GetCourse_FullMethodName          = "/gogermany.catalogue.v1.Catalogue/GetCourse"
+	Catalogue_ListCourses_FullMethodName        = "/gogermany.catalogue.v1.Catalogue/ListCourses"
+	Catalogue_StreamCourses_FullMethodName      = "/gogermany.catalogue.v1.Catalogue/StreamCourses"
+	Catalogue_GetArticle_FullMethodName         = "/gogermany.catalogue.v1.Catalogue/GetArticle"
+	Catalogue_ListArticles_FullMethodName       = "/gogermany.catalogue.v1.Catalogue/ListArticles"
+	Catalogue_StreamArticles_FullMethodName     = "/gogermany.catalogue.v1.Catalogue/StreamArticles"
+	Catalogue_StreamUniversities_FullMethodName = "/gogermany.catalogue.v1.Catalogue/StreamUniversities"
+)
+
+// CatalogueClient is the client API for Catalogue service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogueClient interface {
+	GetCourse(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*Course, error)
+	// ListCourses returns one page of courses, like GET /v1/courses.
+	ListCourses(ctx context.Context, in *CourseParams, opts ...grpc.CallOption) (*CoursePage, error)
+	// StreamCourses sends every course matching the filters, ignoring paging.
+	// Languages are set, articles are not.
+	StreamCourses(ctx context.Context, in *CourseParams, opts ...grpc.CallOption) (Catalogue_StreamCoursesClient, error)
+	GetArticle(ctx context.Context, in *GetArticleRequest, opts ...grpc.CallOption) (*Article, error)
+	// ListArticles returns one page of articles, like GET /v1/articles.
+	ListArticles(ctx context.Context, in *ArticleParams, opts ...grpc.CallOption) (*ArticlePage, error)
+	// StreamArticles sends every article matching the filters, ignoring
+	// paging. The courses are not set.
+	StreamArticles(ctx context.Context, in *ArticleParams, opts ...grpc.CallOption) (Catalogue_StreamArticlesClient, error)
+	StreamUniversities(ctx context.Context, in *StreamUniversitiesRequest, opts ...grpc.CallOption) (Catalogue_StreamUniversitiesClient, error)
+}
+
+type catalogueClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogueClient(cc grpc.ClientConnInterface) CatalogueClient {
+	return &catalogueClient{cc}
+}
+
+func (c *catalogueClient) GetCourse(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*Course, error) {
+	out := new(Course)
+	err := c.cc.Invoke(ctx, Catalogue_GetCourse_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ListCourses(ctx context.Context, in *CourseParams, opts ...grpc.CallOption) (*CoursePage, error) {
+	out := new(CoursePage)
+	err := c.cc.Invoke(ctx, Catalogue_ListCourses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) StreamCourses(ctx context.Context, in *CourseParams, opts ...grpc.CallOption) (Catalogue_StreamCoursesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Catalogue_ServiceDesc.Streams[0], Catalogue_StreamCourses_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogueStreamCoursesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Catalogue_StreamCoursesClient interface {
+	Recv() (*Course, error)
+	grpc.ClientStream
+}
+
+type catalogueStreamCoursesClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogueStreamCoursesClient) Recv() (*Course, error) {
+	m := new(Course)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogueClient) GetArticle(ctx context.Context, in *GetArticleRequest, opts ...grpc.CallOption) (*Article, error) {
+	out := new(Article)
+	err := c.cc.Invoke(ctx, Catalogue_GetArticle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ListArticles(ctx context.Context, in *ArticleParams, opts ...grpc.CallOption) (*ArticlePage, error) {
+	out := new(ArticlePage)
+	err := c.cc.Invoke(ctx, Catalogue_ListArticles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) StreamArticles(ctx context.Context, in *ArticleParams, opts ...grpc.CallOption) (Catalogue_StreamArticlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Catalogue_ServiceDesc.Streams[1], Catalogue_StreamArticles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogueStreamArticlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Catalogue_StreamArticlesClient interface {
+	Recv() (*Article, error)
+	grpc.ClientStream
+}
+
+type catalogueStreamArticlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogueStreamArticlesClient) Recv() (*Article, error) {
+	m := new(Article)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogueClient) StreamUniversities(ctx context.Context, in *StreamUniversitiesRequest, opts ...grpc.CallOption) (Catalogue_StreamUniversitiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Catalogue_ServiceDesc.Streams[2], Catalogue_StreamUniversities_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogueStreamUniversitiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Catalogue_StreamUniversitiesClient interface {
+	Recv() (*University, error)
+	grpc.ClientStream
+}
+
+type catalogueStreamUniversitiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogueStreamUniversitiesClient) Recv() (*University, error) {
+	m := new(University)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CatalogueServer is the server API for Catalogue service.
+// All implementations must embed UnimplementedCatalogueServer
+// for forward compatibility
+type CatalogueServer interface {
+	GetCourse(context.Context, *GetCourseRequest) (*Course, error)
+	// ListCourses returns one page of courses, like GET /v1/courses.
+	ListCourses(context.Context, *CourseParams) (*CoursePage, error)
+	// StreamCourses sends every course matching the filters, ignoring paging.
+	// Languages are set, articles are not.
+	StreamCourses(*CourseParams, Catalogue_StreamCoursesServer) error
+	GetArticle(context.Context, *GetArticleRequest) (*Article, error)
+	// ListArticles returns one page of articles, like GET /v1/articles.
+	ListArticles(context.Context, *ArticleParams) (*ArticlePage, error)
+	// StreamArticles sends every article matching the filters, ignoring
+	// paging. The courses are not set.
+	StreamArticles(*ArticleParams, Catalogue_StreamArticlesServer) error
+	StreamUniversities(*StreamUniversitiesRequest, Catalogue_StreamUniversitiesServer) error
+	mustEmbedUnimplementedCatalogueServer()
+}
+
+// UnimplementedCatalogueServer must be embedded to have forward compatible implementations.
+type UnimplementedCatalogueServer struct {
+}
+
+func (UnimplementedCatalogueServer) GetCourse(context.Context, *GetCourseRequest) (*Course, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourse not implemented")
+}
+func (UnimplementedCatalogueServer) ListCourses(context.Context, *CourseParams) (*CoursePage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCourses not implemented")
+}
+func (UnimplementedCatalogueServer) StreamCourses(*CourseParams, Catalogue_StreamCoursesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCourses not implemented")
+}
+func (UnimplementedCatalogueServer) GetArticle(context.Context, *GetArticleRequest) (*Article, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArticle not implemented")
+}
+func (UnimplementedCatalogueServer) ListArticles(context.Context, *ArticleParams) (*ArticlePage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArticles not implemented")
+}
+func (UnimplementedCatalogueServer) StreamArticles(*ArticleParams, Catalogue_StreamArticlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamArticles not implemented")
+}
+func (UnimplementedCatalogueServer) StreamUniversities(*StreamUniversitiesRequest, Catalogue_StreamUniversitiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUniversities not implemented")
+}
+func (UnimplementedCatalogueServer) mustEmbedUnimplementedCatalogueServer() {}
+
+// UnsafeCatalogueServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogueServer will
+// result in compilation errors.
+type UnsafeCatalogueServer interface {
+	mustEmbedUnimplementedCatalogueServer()
+}
+
+func RegisterCatalogueServer(s grpc.ServiceRegistrar, srv CatalogueServer) {
+	s.RegisterService(&Catalogue_ServiceDesc, srv)
+}
+
+func _Catalogue_GetCourse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).GetCourse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_GetCourse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).GetCourse(ctx, req.(*GetCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ListCourses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CourseParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListCourses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListCourses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListCourses(ctx, req.(*CourseParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_StreamCourses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CourseParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogueServer).StreamCourses(m, &catalogueStreamCoursesServer{stream})
+}
+
+type Catalogue_StreamCoursesServer interface {
+	Send(*Course) error
+	grpc.ServerStream
+}
+
+type catalogueStreamCoursesServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogueStreamCoursesServer) Send(m *Course) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Catalogue_GetArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).GetArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_GetArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).GetArticle(ctx, req.(*GetArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ListArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArticleParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListArticles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListArticles(ctx, req.(*ArticleParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_StreamArticles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ArticleParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogueServer).StreamArticles(m, &catalogueStreamArticlesServer{stream})
+}
+
+type Catalogue_StreamArticlesServer interface {
+	Send(*Article) error
+	grpc.ServerStream
+}
+
+type catalogueStreamArticlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogueStreamArticlesServer) Send(m *Article) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Catalogue_StreamUniversities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamUniversitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogueServer).StreamUniversities(m, &catalogueStreamUniversitiesServer{stream})
+}
+
+type Catalogue_StreamUniversitiesServer interface {
+	Send(*University) error
+	grpc.ServerStream
+}
+
+type catalogueStreamUniversitiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogueStreamUniversitiesServer) Send(m *University) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Catalogue_ServiceDesc is the grpc.ServiceDesc for Catalogue service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Catalogue_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gogermany.catalogue.v1.Catalogue",
+	HandlerType: (*CatalogueServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCourse",
+			Handler:    _Catalogue_GetCourse_Handler,
+		},
+		{
+			MethodName: "ListCourses",
+			Handler:    _Catalogue_ListCourses_Handler,
+		},
+		{
+			MethodName: "GetArticle",
+			Handler:    _Catalogue_GetArticle_Handler,
+		},
+		{
+			MethodName: "ListArticles",
+			Handler:    _Catalogue_ListArticles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCourses",
+			Handler:       _Catalogue_StreamCourses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamArticles",
+			Handler:       _Catalogue_StreamArticles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamUniversities",
+			Handler:       _Catalogue_StreamUniversities_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "catalogue/catalogue.proto",
+}
@@ -0,0 +1,267 @@
+// Package catalogue serves the courses, universities and articles over gRPC.
+// The messages and service are generated from catalogue.proto.
+package catalogue
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements CatalogueServer on top of the models package
+type Server struct {
+	UnimplementedCatalogueServer
+	db     *models.DBModel
+	logger *slog.Logger
+}
+
+// NewServer returns a server reading from db that logs the errors it hides
+// from clients to logger
+func NewServer(db *models.DBModel, logger *slog.Logger) *Server {
+	return &Server{db: db, logger: logger}
+}
+
+func (s *Server) GetCourse(ctx context.Context, req *GetCourseRequest) (*Course, error) {
+	course, err := s.db.WithContext(ctx).Get(int(req.Id))
+	if err != nil {
+		return nil, s.statusError(ctx, err, "course", req.Id)
+	}
+
+	return courseMessage(course), nil
+}
+
+func (s *Server) ListCourses(ctx context.Context, req *CourseParams) (*CoursePage, error) {
+	if req.PageNumber < 1 || req.PageSize < 1 {
+		return nil, status.Error(codes.InvalidArgument, "page_number and page_size must be positive")
+	}
+
+	courses, count, err := s.db.WithContext(ctx).All(courseParams(req))
+	if err != nil {
+		return nil, s.internalError(ctx, err)
+	}
+
+	page := &CoursePage{Pagination: pagination(req.PageNumber, req.PageSize, count)}
+	for _, c := range courses {
+		page.Courses = append(page.Courses, courseMessage(c))
+	}
+
+	return page, nil
+}
+
+func (s *Server) StreamCourses(req *CourseParams, stream Catalogue_StreamCoursesServer) error {
+	err := s.db.WithContext(stream.Context()).EachCourse(courseParams(req), func(c *models.Course) error {
+		return stream.Send(courseMessage(c))
+	})
+	if err != nil {
+		return s.internalError(stream.Context(), err)
+	}
+
+	return nil
+}
+
+func (s *Server) GetArticle(ctx context.Context, req *GetArticleRequest) (*Article, error) {
+	article, err := s.db.WithContext(ctx).GetOneArticle(int(req.Id))
+	if err != nil {
+		return nil, s.statusError(ctx, err, "article", req.Id)
+	}
+
+	return articleMessage(article), nil
+}
+
+func (s *Server) ListArticles(ctx context.Context, req *ArticleParams) (*ArticlePage, error) {
+	if req.PageNumber < 1 || req.PageSize < 1 {
+		return nil, status.Error(codes.InvalidArgument, "page_number and page_size must be positive")
+	}
+
+	articles, count, err := s.db.WithContext(ctx).GetArticles(articleParams(req))
+	if err != nil {
+		return nil, s.internalError(ctx, err)
+	}
+
+	page := &ArticlePage{Pagination: pagination(req.PageNumber, req.PageSize, count)}
+	for _, a := range articles {
+		page.Articles = append(page.Articles, articleMessage(a))
+	}
+
+	return page, nil
+}
+
+func (s *Server) StreamArticles(req *ArticleParams, stream Catalogue_StreamArticlesServer) error {
+	err := s.db.WithContext(stream.Context()).EachArticle(articleParams(req), func(a *models.Article) error {
+		return stream.Send(articleMessage(a))
+	})
+	if err != nil {
+		return s.internalError(stream.Context(), err)
+	}
+
+	return nil
+}
+
+func (s *Server) StreamUniversities(req *StreamUniversitiesRequest, stream Catalogue_StreamUniversitiesServer) error {
+	universities, err := s.db.WithContext(stream.Context()).Universities()
+	if err != nil {
+		return s.internalError(stream.Context(), err)
+	}
+
+	for _, u := range universities {
+		err := stream.Send(&University{
+			Id:         int32(u.ID),
+			NameEn:     u.NameEn,
+			City:       u.City,
+			IsFromDaad: u.IsFromDaad,
+			IsTu9:      u.IsTu9,
+			IsU15:      u.IsU15,
+			QsRanking:  int32(u.QsRanking),
+			Link:       u.Link,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) statusError(ctx context.Context, err error, kind string, id int32) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Errorf(codes.NotFound, "%s %d not found", kind, id)
+	}
+	return s.internalError(ctx, err)
+}
+
+// internalError logs err and returns a status that does not expose it
+func (s *Server) internalError(ctx context.Context, err error) error {
+	s.logger.ErrorContext(ctx, "grpc", "error", err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// courseParams converts the filters like the REST listing reads them from the query
+func courseParams(req *CourseParams) models.CourseParams {
+	return models.CourseParams{
+		PageNumber:           int(req.PageNumber),
+		PageSize:             int(req.PageSize),
+		SearchTerm:           strings.ToLower(req.SearchTerm),
+		CourseTypes:          strings.ToLower(req.CourseTypes),
+		Languages:            strings.ToLower(req.Languages),
+		Subjects:             strings.ToLower(req.Subjects),
+		Institutions:         strings.ToLower(req.Institutions),
+		IsTu9:                req.IsTu9,
+		IsU15:                req.IsU15,
+		HasArticles:          req.HasArticles,
+		OrderBy:              strings.ToLower(req.OrderBy),
+		HideLanguageNArticle: req.HideLanguageArticle,
+	}
+}
+
+func articleParams(req *ArticleParams) models.ArticleParams {
+	return models.ArticleParams{
+		PageNumber:      int(req.PageNumber),
+		PageSize:        int(req.PageSize),
+		SearchTerm:      strings.ToLower(req.SearchTerm),
+		Sources:         strings.ToLower(req.Sources),
+		BsSchools:       strings.ToLower(req.BsSchools),
+		BsDepartments:   strings.ToLower(req.BsDepartments),
+		MsSchools:       strings.ToLower(req.MsSchools),
+		MsDepartments:   strings.ToLower(req.MsDepartments),
+		CourseType:      req.CourseType,
+		HideApplication: req.HideApplication,
+	}
+}
+
+func pagination(pageNumber, pageSize int32, count int) *Pagination {
+	return &Pagination{
+		CurrentPage: pageNumber,
+		PageSize:    pageSize,
+		TotalCount:  int32(count),
+		TotalPages:  int32(math.Ceil(float64(count) / float64(pageSize))),
+	}
+}
+
+func courseMessage(c *models.Course) *Course {
+	universityID, _ := strconv.Atoi(c.UniversityId)
+
+	msg := &Course{
+		Id:                       int32(c.ID),
+		UniversityId:             int32(universityID),
+		CourseType:               c.CourseType,
+		NameEn:                   c.NameEn,
+		NameEnShort:              c.NameEnShort,
+		TuitionFees:              c.TuitionFees,
+		Beginning:                c.Beginning,
+		Subject:                  c.Subject,
+		Daadlink:                 c.Daadlink,
+		IsElearning:              c.IsElearning,
+		ApplicationDeadline:      c.ApplicationDeadline,
+		IsCompleteOnlinePossible: c.IsCompleteOnlinePossible,
+		ProgrammeDuration:        c.ProgrammeDuration,
+		IsFromDaad:               c.IsFromDaad,
+		University: &University{
+			Id:        int32(universityID),
+			NameEn:    c.UniversityNameEn,
+			City:      c.City,
+			IsTu9:     c.IsTu9,
+			IsU15:     c.IsU15,
+			QsRanking: int32(c.QsRanking),
+			Link:      c.UniversityLink,
+		},
+		Languages: c.CourseLanguage,
+	}
+
+	for i := range c.CourseArticle {
+		a := &c.CourseArticle[i]
+		msg.Articles = append(msg.Articles, &CourseArticle{
+			Result:     a.Result,
+			IsDecision: a.IsDecision,
+			Article:    articleMessage(a),
+		})
+	}
+
+	return msg
+}
+
+func articleMessage(a *models.Article) *Article {
+	msg := &Article{
+		Id:                  int32(a.ID),
+		Title:               a.Title,
+		Author:              a.Author,
+		Link:                a.Link,
+		PublishedAt:         timestamppb.New(a.PublishedAt),
+		Source:              a.Source,
+		AuthorBsSchool:      a.AuthorBsSchool,
+		AuthorBsSchoolShort: a.AuthorBsSchoolShort,
+		AuthorBsDepartment:  a.AuthorBsDepartment,
+		AuthorBsGpa:         a.AuthorBsGpa,
+		AuthorMsSchool:      a.AuthorMsSchool,
+		AuthorMsSchoolShort: a.AuthorMsSchoolShort,
+		AuthorMsDepartment:  a.AuthorMsDepartment,
+		AuthorMsGpa:         a.AuthorMsGpa,
+		AuthorToefl:         a.AuthorToefl,
+		AuthorIelts:         a.AuthorIelts,
+		AuthorGre:           a.AuthorGre,
+		AuthorGmat:          a.AuthorGmat,
+		AuthorTestdaf:       a.AuthorTestdaf,
+		AuthorGoethe:        a.AuthorGoethe,
+		CourseType:          a.CourseType,
+		Content:             a.Content,
+	}
+
+	for i := range a.ArticleCourse {
+		ac := &a.ArticleCourse[i]
+		msg.Courses = append(msg.Courses, &ArticleCourse{
+			Result:     ac.Result,
+			IsDecision: ac.IsDecision,
+			Course:     courseMessage(&ac.Course),
+		})
+	}
+
+	return msg
+}
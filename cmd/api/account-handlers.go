@@ -0,0 +1,300 @@
+package main
+
+import (
+	"backend/mailer"
+	"backend/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	verificationTTL  = 24 * time.Hour
+	passwordResetTTL = time.Hour
+	mailTimeout      = time.Minute
+)
+
+type accountRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// register creates an unverified account and mails a verification link. The
+// response is the same when the email is taken, so it does not tell whether
+// an account exists.
+func (app *application) register(w http.ResponseWriter, r *http.Request) {
+	var req accountRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	addr, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil || addr.Address != strings.TrimSpace(req.Email) {
+		app.errorJSON(w, errors.New("invalid email address"))
+		return
+	}
+	email := strings.ToLower(addr.Address)
+
+	err = checkPassword(req.Password, email)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 12)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	id, err := app.db(r).InsertUser(email, string(hash))
+	switch {
+	case errors.Is(err, models.ErrDuplicateEmail):
+	case err != nil:
+		app.errorJSON(w, err)
+		return
+	default:
+		app.sendInBackground(r, "verification", func(ctx context.Context) error {
+			return app.sendVerification(ctx, id, email)
+		})
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusAccepted, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// verifyEmail consumes a verification token
+func (app *application) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req accountRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	_, err = app.db(r).VerifyEmail(req.Token)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// resendVerification mails a new verification link to an unverified account.
+// The response is the same whether or not the account exists.
+func (app *application) resendVerification(w http.ResponseWriter, r *http.Request) {
+	var req accountRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	user, err := app.db(r).GetUser(strings.TrimSpace(req.Email))
+	if err == nil && user.EmailVerifiedAt == nil {
+		app.sendInBackground(r, "verification", func(ctx context.Context) error {
+			return app.sendVerification(ctx, user.ID, user.Email)
+		})
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusAccepted, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// forgotPassword mails a password reset link. The response is the same
+// whether or not the account exists.
+func (app *application) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req accountRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	user, err := app.db(r).GetUser(strings.TrimSpace(req.Email))
+	if err == nil {
+		app.sendInBackground(r, "password reset", func(ctx context.Context) error {
+			return app.sendPasswordReset(ctx, user.ID, user.Email)
+		})
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusAccepted, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// resetPassword consumes a password reset token, sets the new password and
+// clears the sign in lockout of the account
+func (app *application) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var req accountRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	user, err := app.db(r).GetUserByToken(req.Token, models.ScopePasswordReset)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = checkPassword(req.Password, user.Email)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 12)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	_, err = app.db(r).ResetPassword(req.Token, string(hash))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	// the owner proved access to the mailbox, which lifts a lockout
+	app.signInGuard.succeed(strings.ToLower(user.Email))
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// sendInBackground runs send after the response is written, so handlers
+// answer as fast whether or not they mail anything and an account cannot be
+// told apart by timing. serve waits for pending mails on shutdown.
+func (app *application) sendInBackground(r *http.Request, kind string, send func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), mailTimeout)
+
+	app.mails.Add(1)
+	go func() {
+		defer app.mails.Done()
+		defer cancel()
+
+		err := send(ctx)
+		if err != nil {
+			app.logger.ErrorContext(ctx, "sending "+kind+" mail", "error", err)
+		}
+	}()
+}
+
+func (app *application) sendVerification(ctx context.Context, userID int, email string) error {
+	token, err := app.models.DB.WithContext(ctx).NewUserToken(userID, models.ScopeVerification, verificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return app.mailer.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Verify your Go Germany account",
+		Body: fmt.Sprintf("Open the link below to verify your email address. It expires in %s.\n\n%s\n",
+			verificationTTL, app.accountLink("verify-email", token)),
+	})
+}
+
+func (app *application) sendPasswordReset(ctx context.Context, userID int, email string) error {
+	token, err := app.models.DB.WithContext(ctx).NewUserToken(userID, models.ScopePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return app.mailer.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Reset your Go Germany password",
+		Body: fmt.Sprintf("Open the link below to choose a new password. It expires in %s.\n"+
+			"If you did not ask for a reset you can ignore this mail.\n\n%s\n",
+			passwordResetTTL, app.accountLink("reset-password", token)),
+	})
+}
+
+// accountLink builds a frontend link carrying a user token
+func (app *application) accountLink(page, token string) string {
+	return strings.TrimRight(app.config.mail.appURL, "/") + "/" + page + "?token=" + url.QueryEscape(token)
+}
+
+// checkPassword enforces the password policy: 10 to 72 bytes, at least one
+// letter and one digit, and different from the email address
+func checkPassword(password, email string) error {
+	if len(password) < 10 {
+		return errors.New("password must be at least 10 characters long")
+	}
+	if len(password) > 72 {
+		return errors.New("password must be at most 72 bytes long")
+	}
+
+	var letter, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	if !letter || !digit {
+		return errors.New("password must contain a letter and a digit")
+	}
+
+	if email != "" && strings.EqualFold(password, email) {
+		return errors.New("password must not be the email address")
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"backend/mailer"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckPassword(t *testing.T) {
+	tests := []struct {
+		password, email string
+		ok              bool
+	}{
+		{"correct horse 1", "a@example.com", true},
+		{"short1", "", false},
+		{"no digits in here", "", false},
+		{"1234567890", "", false},
+		{strings.Repeat("a1", 37), "", false},
+		{"a1@example.com", "A1@example.com", false},
+	}
+	for _, tt := range tests {
+		err := checkPassword(tt.password, tt.email)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkPassword(%q, %q) = %v, want ok %v", tt.password, tt.email, err, tt.ok)
+		}
+	}
+}
+
+// mailedToken returns the token of the link in the latest mail to email
+func mailedToken(t *testing.T, m *mailer.Memory, email string) string {
+	t.Helper()
+
+	msg, ok := m.Last(email)
+	if !ok {
+		t.Fatalf("no mail to %s", email)
+	}
+	i := strings.Index(msg.Body, "http")
+	if i < 0 {
+		t.Fatalf("no link in %q", msg.Body)
+	}
+	link, err := url.Parse(strings.Fields(msg.Body[i:])[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestAccountFlow(t *testing.T) {
+	app := newTestApplication(t)
+	withTestDB(t, app)
+	mails := app.mailer.(*mailer.Memory)
+	h := app.routes()
+
+	email := fmt.Sprintf("test-%d@example.com", time.Now().UnixNano())
+	password := "first password 1"
+	t.Cleanup(func() {
+		app.models.DB.DB.Exec(`delete from gogermany_user where email = $1`, email)
+	})
+
+	rec := do(t, h, http.MethodPost, "/v1/account/register", accountRequest{Email: email, Password: password})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("register: got %d %s", rec.Code, rec.Body)
+	}
+	first := rec.Body.String()
+	app.mails.Wait()
+	verification := mailedToken(t, mails, email)
+
+	// registering a taken email looks the same and mails nothing
+	sent := len(mails.Messages())
+	rec = do(t, h, http.MethodPost, "/v1/account/register", accountRequest{Email: email, Password: "other password 2"})
+	if rec.Code != http.StatusAccepted || rec.Body.String() != first {
+		t.Errorf("register again: got %d %s, want the first response", rec.Code, rec.Body)
+	}
+	app.mails.Wait()
+	if len(mails.Messages()) != sent {
+		t.Error("register again sent a mail")
+	}
+
+	rec = do(t, h, http.MethodPost, "/v1/account/verify", accountRequest{Token: verification})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("verify: got %d %s", rec.Code, rec.Body)
+	}
+	rec = do(t, h, http.MethodPost, "/v1/account/verify", accountRequest{Token: verification})
+	if rec.Code == http.StatusOK {
+		t.Error("verification token was accepted twice")
+	}
+
+	rec = do(t, h, http.MethodPost, "/v1/account/password/forgot", accountRequest{Email: email})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("forgot: got %d %s", rec.Code, rec.Body)
+	}
+	app.mails.Wait()
+	reset := mailedToken(t, mails, email)
+
+	rec = do(t, h, http.MethodPost, "/v1/account/password/reset", accountRequest{Token: reset, Password: strings.ToUpper(email)})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("reset to the email: got %d %s, want 400", rec.Code, rec.Body)
+	}
+
+	rec = do(t, h, http.MethodPost, "/v1/account/password/reset", accountRequest{Token: reset, Password: "second password 2"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reset: got %d %s", rec.Code, rec.Body)
+	}
+	rec = do(t, h, http.MethodPost, "/v1/account/password/reset", accountRequest{Token: reset, Password: "third password 3"})
+	if rec.Code == http.StatusOK {
+		t.Error("reset token was accepted twice")
+	}
+
+	rec = do(t, h, http.MethodPost, "/v1/account/password/forgot", accountRequest{Email: "nobody-" + email})
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("forgot for an unknown email: got %d %s", rec.Code, rec.Body)
+	}
+}
+
+// blockingMailer holds every mail until release is closed
+type blockingMailer struct {
+	mailer.Memory
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	close(m.started)
+	<-m.release
+	return m.Memory.Send(ctx, msg)
+}
+
+func TestSendInBackground(t *testing.T) {
+	app := newTestApplication(t)
+	m := &blockingMailer{started: make(chan struct{}), release: make(chan struct{})}
+
+	// the request context ends with the handler, the mail must still go out
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest(http.MethodPost, "/v1/account/password/forgot", nil).WithContext(ctx)
+	app.sendInBackground(r, "test", func(ctx context.Context) error {
+		return m.Send(ctx, mailer.Message{To: "a@example.com"})
+	})
+	cancel()
+
+	<-m.started
+	close(m.release)
+	app.mails.Wait()
+
+	if _, ok := m.Last("a@example.com"); !ok {
+		t.Error("mail was not sent")
+	}
+}
+
+func TestRegisterDoesNotWaitForMail(t *testing.T) {
+	app := newTestApplication(t)
+	withTestDB(t, app)
+	m := &blockingMailer{started: make(chan struct{}), release: make(chan struct{})}
+	app.mailer = m
+
+	email := fmt.Sprintf("test-%d@example.com", time.Now().UnixNano())
+	t.Cleanup(func() {
+		app.models.DB.DB.Exec(`delete from gogermany_user where email = $1`, email)
+	})
+
+	rec := do(t, app.routes(), http.MethodPost, "/v1/account/register", accountRequest{Email: email, Password: "first password 1"})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("register: got %d %s", rec.Code, rec.Body)
+	}
+
+	// the response was written while the mail is still held
+	<-m.started
+	if _, ok := m.Last(email); ok {
+		t.Fatal("mail was sent before the response")
+	}
+
+	close(m.release)
+	app.mails.Wait()
+	if _, ok := m.Last(email); !ok {
+		t.Error("verification mail was not sent")
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"backend/models"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// tokenBuckets holds in-memory rate limit buckets, one per api key or client address
+type tokenBuckets struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	swept   time.Time
+}
+
+// tokenBucket is dropped once full, which is the same as starting a new one
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time
+}
+
+func newTokenBuckets() *tokenBuckets {
+	return &tokenBuckets{buckets: make(map[string]*tokenBucket)}
+}
+
+// take removes a token from the bucket of key, refilled at ratePerMinute
+// up to burst. It returns the tokens left and, when the bucket is empty, how
+// long until the next token.
+func (tb *tokenBuckets) take(key string, ratePerMinute float64, burst int) (bool, int, time.Duration) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := time.Now()
+	perSecond := ratePerMinute / 60
+
+	if now.Sub(tb.swept) > time.Minute {
+		tb.swept = now
+		for k, b := range tb.buckets {
+			if now.After(b.full) {
+				delete(tb.buckets, k)
+			}
+		}
+	}
+
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(burst), last: now}
+		tb.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+
+	ok = b.tokens >= 1
+	if ok {
+		b.tokens--
+	}
+
+	b.full = now.Add(24 * time.Hour)
+	if perSecond > 0 {
+		b.full = now.Add(time.Duration((float64(burst) - b.tokens) / perSecond * float64(time.Second)))
+	}
+
+	if !ok {
+		if perSecond <= 0 {
+			return false, 0, time.Minute
+		}
+		return false, 0, time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+	}
+	return true, int(b.tokens), 0
+}
+
+// public registers a GET route open to anonymous clients and to api key
+// holders, whose requests are limited and counted under pattern
+func (app *application) public(router *apiRouter, pattern string, policy cachePolicy, handler http.HandlerFunc) {
+	router.Handler(http.MethodGet, pattern, app.apiKey(pattern, app.cached(policy, handler)))
+}
+
+// apiKey enforces the rate limit and daily quota of the key sent in the
+// X-API-Key header. Requests without a key pass unless keys are required.
+func (app *application) apiKey(endpoint string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, err := app.useAPIKey(app.db(r), r.Header.Get("X-API-Key"), endpoint, w.Header())
+		if err != nil {
+			app.errorJSON(w, err, status)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// useAPIKey counts a request to endpoint against key and sets the rate limit
+// and quota headers on h. When the request is refused it returns the status
+// to answer with and the reason. The REST routes and the gRPC service share it.
+func (app *application) useAPIKey(db *models.DBModel, key, endpoint string, h http.Header) (int, error) {
+	if key == "" {
+		if app.config.apiKeysRequired {
+			return http.StatusUnauthorized, errors.New("an api key is required")
+		}
+		return http.StatusOK, nil
+	}
+
+	k, err := db.GetAPIKeyByKey(key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusUnauthorized, errors.New("invalid api key")
+	}
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if k.Status != "active" {
+		return http.StatusForbidden, errors.New("api key is " + k.Status)
+	}
+
+	ok, remaining, wait := app.apiKeyBuckets.take(strconv.Itoa(k.ID), float64(k.RatePerMinute), k.Burst)
+	h.Set("X-RateLimit-Limit", strconv.Itoa(k.RatePerMinute))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	if !ok {
+		retry := strconv.Itoa(int(math.Ceil(wait.Seconds())))
+		h.Set("X-RateLimit-Reset", retry)
+		h.Set("Retry-After", retry)
+		return http.StatusTooManyRequests, errors.New("rate limit exceeded")
+	}
+
+	today, err := db.CountAPIKeyRequest(k.ID, endpoint)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	left := k.DailyQuota - today
+	if left < 0 {
+		left = 0
+	}
+	h.Set("X-Quota-Limit", strconv.Itoa(k.DailyQuota))
+	h.Set("X-Quota-Remaining", strconv.Itoa(left))
+	h.Set("X-Quota-Reset", strconv.Itoa(int(midnight.Sub(now).Seconds())))
+	if today > k.DailyQuota {
+		h.Set("Retry-After", strconv.Itoa(int(midnight.Sub(now).Seconds())))
+		return http.StatusTooManyRequests, errors.New("daily quota exceeded")
+	}
+
+	return http.StatusOK, nil
+}
+
+// issuedAPIKey is a new key with its plaintext
+type issuedAPIKey struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// createAPIKey issues a key from a json body {"name", "owner_email",
+// "rate_per_minute", "burst", "daily_quota"}. The key is only shown in this response.
+func (app *application) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var k models.APIKey
+
+	err := json.NewDecoder(r.Body).Decode(&k)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	k.Name = strings.TrimSpace(k.Name)
+	if k.Name == "" {
+		app.errorJSON(w, errors.New("name is required"))
+		return
+	}
+	if k.RatePerMinute == 0 {
+		k.RatePerMinute = 60
+	}
+	if k.Burst == 0 {
+		k.Burst = k.RatePerMinute
+	}
+	if k.DailyQuota == 0 {
+		k.DailyQuota = 10000
+	}
+	if k.RatePerMinute < 0 || k.Burst < 0 || k.DailyQuota < 0 {
+		app.errorJSON(w, errors.New("limits must be positive"))
+		return
+	}
+
+	id := userID(r)
+	k.CreatedBy = &id
+
+	created, key, err := app.db(r).InsertAPIKey(k)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, issuedAPIKey{APIKey: created, Key: key}, "api_key")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := app.db(r).GetAPIKeys()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, keys, "api_keys")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// getAPIKey returns a key with its usage per endpoint over the last ?days=30
+func (app *application) getAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil || days <= 0 {
+		days = 30
+	}
+
+	k, err := app.db(r).GetAPIKey(id, days)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, k, "api_key")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) suspendAPIKey(w http.ResponseWriter, r *http.Request) {
+	app.setAPIKeyStatus(w, r, "suspended")
+}
+
+func (app *application) activateAPIKey(w http.ResponseWriter, r *http.Request) {
+	app.setAPIKeyStatus(w, r, "active")
+}
+
+func (app *application) setAPIKeyStatus(w http.ResponseWriter, r *http.Request, status string) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	err = app.db(r).SetAPIKeyStatus(id, status)
+	if errors.Is(err, sql.ErrNoRows) {
+		app.errorJSON(w, fmt.Errorf("api key %d not found", id), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketsTake(t *testing.T) {
+	tb := newTokenBuckets()
+
+	for i := 0; i < 3; i++ {
+		ok, remaining, _ := tb.take("a", 60, 3)
+		if !ok || remaining != 2-i {
+			t.Fatalf("take %d: got ok %v remaining %d", i, ok, remaining)
+		}
+	}
+
+	ok, _, wait := tb.take("a", 60, 3)
+	if ok || wait <= 0 || wait > time.Second {
+		t.Errorf("got ok %v wait %s, want a refused take and a wait of at most a second", ok, wait)
+	}
+
+	if ok, _, _ := tb.take("b", 60, 3); !ok {
+		t.Error("buckets of other keys are shared")
+	}
+}
+
+func TestTokenBucketsDropFullBuckets(t *testing.T) {
+	tb := newTokenBuckets()
+	tb.take("refilled", 60, 1)
+	tb.take("empty", 0, 1)
+
+	// pretend a sweep is due and the refilled bucket is full again
+	tb.swept = time.Now().Add(-2 * time.Minute)
+	tb.buckets["refilled"].full = time.Now().Add(-time.Second)
+	tb.take("other", 60, 1)
+
+	if _, ok := tb.buckets["refilled"]; ok {
+		t.Error("full bucket was kept")
+	}
+	if _, ok := tb.buckets["empty"]; !ok {
+		t.Error("bucket that does not refill was dropped")
+	}
+}
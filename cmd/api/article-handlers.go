@@ -3,8 +3,6 @@ package main
 import (
 	"backend/models"
 	"encoding/json"
-	"errors"
-	"log"
 	"math"
 	"net/http"
 	"strconv"
@@ -18,17 +16,24 @@ func (app *application) getOneArticle(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		app.logger.Print(errors.New("invalid id parameter"))
 		app.errorJSON(w, err)
 		return
 	}
 
-	article, err := app.models.DB.GetOneArticle(id)
+	article, err := app.db(r).GetOneArticle(id)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
 	}
 
+	if withLinkStatus(r) {
+		err = app.setArticleLinkStatus(r, article)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	}
+
 	err = app.writeJSON(w, http.StatusOK, article, "article")
 	if err != nil {
 		app.errorJSON(w, err)
@@ -37,7 +42,7 @@ func (app *application) getOneArticle(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) getAllArticles(w http.ResponseWriter, r *http.Request) {
-	log.Println("GET /v1/articles", r.URL.Query())
+	app.logger.DebugContext(r.Context(), "list articles", "query", r.URL.RawQuery)
 	pn, err := strconv.Atoi(r.URL.Query().Get("pageNumber"))
 	if err != nil {
 		app.errorJSON(w, err)
@@ -50,33 +55,24 @@ func (app *application) getAllArticles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	st := r.URL.Query().Get("searchTerm")
-	srcs := r.URL.Query().Get("sources")
-	bschs := r.URL.Query().Get("bsSchools")
-	bsds := r.URL.Query().Get("bsDepartments")
-	mschs := r.URL.Query().Get("msSchools")
-	msds := r.URL.Query().Get("msDepartments")
-	ct := r.URL.Query().Get("courseType")
-	ha, _ := strconv.ParseBool(r.URL.Query().Get("hideApplication"))
-
-	var ap models.ArticleParams
+	ap := articleParams(r)
 	ap.PageNumber = pn
 	ap.PageSize = ps
-	ap.SearchTerm = strings.ToLower(st)
-	ap.Sources = strings.ToLower(srcs)
-	ap.BsSchools = strings.ToLower(bschs)
-	ap.BsDepartments = strings.ToLower(bsds)
-	ap.MsSchools = strings.ToLower(mschs)
-	ap.MsDepartments = strings.ToLower(msds)
-	ap.CourseType = ct
-	ap.HideApplication = ha
 
-	articles, count, err := app.models.DB.GetArticles(ap)
+	articles, count, err := app.db(r).GetArticles(ap)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
 	}
 
+	if withLinkStatus(r) {
+		err = app.setArticleLinkStatus(r, articles...)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	}
+
 	var md MetaData
 	md.PageSize = ps
 	md.CurrentPage = pn
@@ -94,8 +90,32 @@ func (app *application) getAllArticles(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// articleParams reads the article listing filters shared by the listing and export endpoints
+func articleParams(r *http.Request) models.ArticleParams {
+	st := r.URL.Query().Get("searchTerm")
+	srcs := r.URL.Query().Get("sources")
+	bschs := r.URL.Query().Get("bsSchools")
+	bsds := r.URL.Query().Get("bsDepartments")
+	mschs := r.URL.Query().Get("msSchools")
+	msds := r.URL.Query().Get("msDepartments")
+	ct := r.URL.Query().Get("courseType")
+	ha, _ := strconv.ParseBool(r.URL.Query().Get("hideApplication"))
+
+	var ap models.ArticleParams
+	ap.SearchTerm = strings.ToLower(st)
+	ap.Sources = strings.ToLower(srcs)
+	ap.BsSchools = strings.ToLower(bschs)
+	ap.BsDepartments = strings.ToLower(bsds)
+	ap.MsSchools = strings.ToLower(mschs)
+	ap.MsDepartments = strings.ToLower(msds)
+	ap.CourseType = ct
+	ap.HideApplication = ha
+
+	return ap
+}
+
 func (app *application) getArticleFilters(w http.ResponseWriter, r *http.Request) {
-	filters, err := app.models.DB.GetArticleFilters()
+	filters, err := app.filters.articleFilters(app.db(r))
 	if err != nil {
 		app.errorJSON(w, err)
 		return
@@ -119,7 +139,7 @@ func (app *application) editArticle(w http.ResponseWriter, r *http.Request) {
 	ca.Result = r.FormValue("result")
 	ca.IsDecision, _ = strconv.ParseBool(r.FormValue("isDecision"))
 
-	err := app.models.DB.InsertArticle(ca)
+	err := app.db(r).InsertArticle(ca)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cachePolicy sets how long the responses of a public route are kept by the
+// response cache and by clients. The zero value caches nothing.
+type cachePolicy struct {
+	server time.Duration
+	maxAge time.Duration
+}
+
+var (
+	noCache      = cachePolicy{}
+	listingCache = cachePolicy{server: 5 * time.Minute, maxAge: time.Minute}
+	filterCache  = cachePolicy{server: time.Hour, maxAge: 5 * time.Minute}
+)
+
+// responseCache keeps successful GET responses in memory until they expire
+// or an admin write purges them
+type responseCache struct {
+	mu         sync.RWMutex
+	entries    map[string]*cachedResponse
+	maxEntries int
+	generation int
+}
+
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func newResponseCache(maxEntries int) *responseCache {
+	return &responseCache{
+		entries:    make(map[string]*cachedResponse),
+		maxEntries: maxEntries,
+	}
+}
+
+// get returns the entry of key and the generation to pass to set when
+// there is none
+func (c *responseCache) get(key string) (*cachedResponse, int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, c.generation, false
+	}
+	return e, c.generation, true
+}
+
+// set stores e unless the cache was purged since generation, as e may have
+// been read before the write that caused the purge
+func (c *responseCache) set(key string, e *cachedResponse, generation int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		now := time.Now()
+		for k, old := range c.entries {
+			if now.After(old.expires) {
+				delete(c.entries, k)
+			}
+		}
+		// still full, drop an arbitrary entry
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = e
+}
+
+// purge drops every entry
+func (c *responseCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]*cachedResponse)
+}
+
+// cacheKey is the path with the non empty query parameters sorted by name
+func cacheKey(r *http.Request) string {
+	query := r.URL.Query()
+	names := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, v := range values {
+			if v != "" {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(r.URL.Path)
+	for i, name := range names {
+		if i == 0 {
+			b.WriteByte('?')
+		} else {
+			b.WriteByte('&')
+		}
+		for j, v := range query[name] {
+			if j > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(url.QueryEscape(name) + "=" + url.QueryEscape(v))
+		}
+	}
+	return b.String()
+}
+
+// cached serves GET responses of next from the response cache and sets the
+// Cache-Control header of the policy
+func (app *application) cached(policy cachePolicy, next http.Handler) http.Handler {
+	cacheControl := "no-cache"
+	if policy.maxAge > 0 {
+		cacheControl = "public, max-age=" + strconv.Itoa(int(policy.maxAge.Seconds()))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if policy.server <= 0 || app.cache == nil || app.cache.maxEntries <= 0 {
+			w.Header().Set("Cache-Control", cacheControl)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := cacheKey(r)
+		e, generation, ok := app.cache.get(key)
+		app.metrics.cacheLookup(r, ok)
+		if ok {
+			writeCached(w, e, "HIT")
+			return
+		}
+
+		rec := &responseRecorder{parent: w, header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.status == http.StatusOK {
+			rec.header.Set("Cache-Control", cacheControl)
+		} else {
+			rec.header.Set("Cache-Control", "no-store")
+		}
+
+		e = &cachedResponse{
+			status:  rec.status,
+			header:  rec.header,
+			body:    rec.body.Bytes(),
+			expires: time.Now().Add(policy.server),
+		}
+		if rec.status == http.StatusOK {
+			app.cache.set(key, e, generation)
+		}
+		writeCached(w, e, "MISS")
+	})
+}
+
+func writeCached(w http.ResponseWriter, e *cachedResponse, xCache string) {
+	for k, v := range e.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("X-Cache", xCache)
+	w.WriteHeader(e.status)
+	w.Write(e.body)
+}
+
+// invalidateCache purges the caches after a successful write. Other instances
+// learn about the write from the catalogue triggers.
+func (app *application) invalidateCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || app.cache == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		if sw.status < 400 {
+			app.purgeCaches()
+		}
+	})
+}
+
+// responseRecorder buffers a response so it can be cached
+type responseRecorder struct {
+	parent http.ResponseWriter
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.parent
+}
+
+// statusWriter remembers the status written to w
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// etag returns a strong entity tag of body
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// conditionalGET answers GET requests with 304 Not Modified when the ETag
+// set by writeJSON matches the If-None-Match header
+func (app *application) conditionalGET(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inm := r.Header.Get("If-None-Match")
+		if r.Method != http.MethodGet || inm == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(&conditionalWriter{ResponseWriter: w, ifNoneMatch: inm}, r)
+	})
+}
+
+type conditionalWriter struct {
+	http.ResponseWriter
+	ifNoneMatch string
+	wroteHeader bool
+	notModified bool
+}
+
+func (cw *conditionalWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+
+	tag := cw.Header().Get("ETag")
+	if status == http.StatusOK && tag != "" && etagMatches(cw.ifNoneMatch, tag) {
+		cw.notModified = true
+		cw.Header().Del("Content-Type")
+		cw.Header().Del("Content-Length")
+		cw.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *conditionalWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.notModified {
+		return len(b), nil
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *conditionalWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// etagMatches compares the If-None-Match list with tag, ignoring weakness
+// as RFC 9110 requires for If-None-Match
+func etagMatches(ifNoneMatch, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheSetAfterPurge(t *testing.T) {
+	c := newResponseCache(10)
+
+	_, generation, _ := c.get("/v1/courses")
+	c.purge()
+	c.set("/v1/courses", &cachedResponse{status: http.StatusOK, expires: time.Now().Add(time.Minute)}, generation)
+
+	if _, _, ok := c.get("/v1/courses"); ok {
+		t.Error("response read before the purge was cached")
+	}
+
+	_, generation, _ = c.get("/v1/courses")
+	c.set("/v1/courses", &cachedResponse{status: http.StatusOK, expires: time.Now().Add(time.Minute)}, generation)
+
+	if _, _, ok := c.get("/v1/courses"); !ok {
+		t.Error("response read after the purge was not cached")
+	}
+}
+
+func TestCachedSkipsResponsesOfPurgedGeneration(t *testing.T) {
+	app := newTestApplication(t)
+
+	// the first request sees a write land while it reads
+	purge := true
+	h := app.cached(listingCache, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if purge {
+			purge = false
+			app.cache.purge()
+		}
+		w.Write([]byte("ok"))
+	}))
+
+	get := func() string {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/courses", nil))
+		return rec.Header().Get("X-Cache")
+	}
+
+	for i, want := range []string{"MISS", "MISS", "HIT"} {
+		if got := get(); got != want {
+			t.Errorf("request %d: got X-Cache %s, want %s", i, got, want)
+		}
+	}
+}
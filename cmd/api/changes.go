@@ -0,0 +1,116 @@
+package main
+
+import (
+	"backend/models"
+	"context"
+	"sync"
+)
+
+// catalogueFilters keeps the course and article filters in memory until the
+// catalogue changes
+type catalogueFilters struct {
+	mu         sync.Mutex
+	generation int
+	courses    *models.Filters
+	articles   *models.ArticleFilters
+}
+
+// reset drops both filters. Filters loaded while the reset happens are not kept.
+func (f *catalogueFilters) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.generation++
+	f.courses = nil
+	f.articles = nil
+}
+
+func (f *catalogueFilters) courseFilters(db *models.DBModel) (*models.Filters, error) {
+	f.mu.Lock()
+	filters, generation := f.courses, f.generation
+	f.mu.Unlock()
+
+	if filters != nil {
+		return filters, nil
+	}
+
+	filters, err := db.GetFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	if f.generation == generation {
+		f.courses = filters
+	}
+	f.mu.Unlock()
+
+	return filters, nil
+}
+
+func (f *catalogueFilters) articleFilters(db *models.DBModel) (*models.ArticleFilters, error) {
+	f.mu.Lock()
+	filters, generation := f.articles, f.generation
+	f.mu.Unlock()
+
+	if filters != nil {
+		return filters, nil
+	}
+
+	filters, err := db.GetArticleFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	if f.generation == generation {
+		f.articles = filters
+	}
+	f.mu.Unlock()
+
+	return filters, nil
+}
+
+// purgeCaches drops everything kept in memory about the catalogue
+func (app *application) purgeCaches() {
+	app.cache.purge()
+	app.filters.reset()
+}
+
+// listenForChanges purges the caches whenever any instance writes to the
+// catalogue and loads the filters again right away. Bursts of changes, like
+// an import, are coalesced into a single reload. It returns once ctx is done.
+func (app *application) listenForChanges(ctx context.Context) {
+	reload := make(chan struct{}, 1)
+	reloaded := make(chan struct{})
+	go func() {
+		defer close(reloaded)
+		for range reload {
+			_, err := app.filters.courseFilters(&app.models.DB)
+			if err != nil {
+				app.logger.Error("reload filters", "error", err)
+			}
+			_, err = app.filters.articleFilters(&app.models.DB)
+			if err != nil {
+				app.logger.Error("reload filters", "error", err)
+			}
+		}
+	}()
+	defer func() {
+		close(reload)
+		<-reloaded
+	}()
+
+	err := models.ListenChanges(ctx, app.config.db.dsn, app.logger, func(table string) {
+		app.logger.Debug("catalogue changed", "table", table)
+		app.purgeCaches()
+
+		select {
+		case reload <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		app.logger.Error("listen", "error", err)
+	}
+}
@@ -10,6 +10,31 @@ import (
 func (app *application) editContent(w http.ResponseWriter, r *http.Request) {
 	r.ParseMultipartForm(0)
 
+	c := contentFromForm(r)
+
+	err := app.db(r).InsertContent(c)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	// type jsonResp struct {
+	// 	OK bool `json:"ok"`
+	// }
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// contentFromForm reads the content fields of the admin multipart form
+func contentFromForm(r *http.Request) models.Content {
 	var c models.Content
 
 	layout := "2006-01-02"
@@ -39,23 +64,5 @@ func (app *application) editContent(w http.ResponseWriter, r *http.Request) {
 	c.CourseType = r.FormValue("courseType")
 	c.Content = r.FormValue("content")
 
-	err := app.models.DB.InsertContent(c)
-	if err != nil {
-		app.errorJSON(w, err)
-		return
-	}
-
-	// type jsonResp struct {
-	// 	OK bool `json:"ok"`
-	// }
-
-	ok := jsonResp{
-		OK: true,
-	}
-
-	err = app.writeJSON(w, http.StatusOK, ok, "response")
-	if err != nil {
-		app.errorJSON(w, err)
-		return
-	}
+	return c
 }
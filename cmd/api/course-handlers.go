@@ -4,7 +4,6 @@ import (
 	"backend/models"
 	"encoding/json"
 	"errors"
-	"log"
 	"math"
 	"net/http"
 	"strconv"
@@ -31,17 +30,24 @@ func (app *application) getOneCourse(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		app.logger.Print(errors.New("invalid id parameter"))
 		app.errorJSON(w, err)
 		return
 	}
 
-	course, err := app.models.DB.Get(id)
+	course, err := app.db(r).Get(id)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
 	}
 
+	if withLinkStatus(r) {
+		err = app.setCourseLinkStatus(r, course)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	}
+
 	err = app.writeJSON(w, http.StatusOK, course, "course")
 	if err != nil {
 		app.errorJSON(w, err)
@@ -50,7 +56,7 @@ func (app *application) getOneCourse(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) getAllCourses(w http.ResponseWriter, r *http.Request) {
-	log.Println("GET /v1/courses", r.URL.Query())
+	app.logger.DebugContext(r.Context(), "list courses", "query", r.URL.RawQuery)
 	pn, err := strconv.Atoi(r.URL.Query().Get("pageNumber"))
 	if err != nil {
 		app.errorJSON(w, err)
@@ -62,40 +68,28 @@ func (app *application) getAllCourses(w http.ResponseWriter, r *http.Request) {
 		app.errorJSON(w, err)
 		return
 	}
-	st := r.URL.Query().Get("searchTerm")
-	ct := r.URL.Query().Get("courseTypes")
-	lngs := r.URL.Query().Get("languages")
-	sjts := r.URL.Query().Get("subjects")
-	insts := r.URL.Query().Get("institutions")
-	ist9, _ := strconv.ParseBool(r.URL.Query().Get("isTu9"))
-	isu15, _ := strconv.ParseBool(r.URL.Query().Get("isU15"))
-	ha, _ := strconv.ParseBool(r.URL.Query().Get("hasArticles"))
-	o := r.URL.Query().Get("orderBy")
-	hla, _ := strconv.ParseBool(r.URL.Query().Get("hideLanguageArticle"))
 
-	var cp models.CourseParams
+	cp := courseParams(r)
 	cp.PageNumber = pn
 	cp.PageSize = ps
-	cp.Languages = strings.ToLower(lngs)
-	cp.Subjects = strings.ToLower(sjts)
-	cp.SearchTerm = strings.ToLower(st)
-	cp.CourseTypes = strings.ToLower(ct)
-	cp.Institutions = strings.ToLower(insts)
-	cp.IsTu9 = ist9
-	cp.IsU15 = isu15
-	cp.HasArticles = ha
-	cp.OrderBy = strings.ToLower(o)
-	cp.HideLanguageNArticle = hla
 
 	// courses, err := app.models.DB.All(pn, ps)
 
 	//return total count from all
-	courses, count, err := app.models.DB.All(cp)
+	courses, count, err := app.db(r).All(cp)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
 	}
 
+	if withLinkStatus(r) {
+		err = app.setCourseLinkStatus(r, courses...)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	}
+
 	// // count have to be adjusted later depends on filters
 	// count, err := app.models.DB.Count()
 	// if err != nil {
@@ -122,8 +116,36 @@ func (app *application) getAllCourses(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// courseParams reads the course listing filters shared by the listing and export endpoints
+func courseParams(r *http.Request) models.CourseParams {
+	st := r.URL.Query().Get("searchTerm")
+	ct := r.URL.Query().Get("courseTypes")
+	lngs := r.URL.Query().Get("languages")
+	sjts := r.URL.Query().Get("subjects")
+	insts := r.URL.Query().Get("institutions")
+	ist9, _ := strconv.ParseBool(r.URL.Query().Get("isTu9"))
+	isu15, _ := strconv.ParseBool(r.URL.Query().Get("isU15"))
+	ha, _ := strconv.ParseBool(r.URL.Query().Get("hasArticles"))
+	o := r.URL.Query().Get("orderBy")
+	hla, _ := strconv.ParseBool(r.URL.Query().Get("hideLanguageArticle"))
+
+	var cp models.CourseParams
+	cp.Languages = strings.ToLower(lngs)
+	cp.Subjects = strings.ToLower(sjts)
+	cp.SearchTerm = strings.ToLower(st)
+	cp.CourseTypes = strings.ToLower(ct)
+	cp.Institutions = strings.ToLower(insts)
+	cp.IsTu9 = ist9
+	cp.IsU15 = isu15
+	cp.HasArticles = ha
+	cp.OrderBy = strings.ToLower(o)
+	cp.HideLanguageNArticle = hla
+
+	return cp
+}
+
 func (app *application) getFilters(w http.ResponseWriter, r *http.Request) {
-	filters, err := app.models.DB.GetFilters()
+	filters, err := app.filters.courseFilters(app.db(r))
 	if err != nil {
 		app.errorJSON(w, err)
 		return
@@ -136,6 +158,59 @@ func (app *application) getFilters(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (app *application) compareCourses(w http.ResponseWriter, r *http.Request) {
+	ids, err := compareIDs(r.URL.Query().Get("ids"))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	comparison, err := app.db(r).Compare(ids)
+	var notFound *models.CourseNotFoundError
+	if errors.As(err, &notFound) {
+		app.errorJSON(w, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		app.errorJSON(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, comparison, "comparison")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// compareIDs parses the comma separated ids of a comparison, dropping
+// repeated ones, and requires 2 to 5 of them
+func compareIDs(param string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool)
+
+	for _, s := range strings.Split(param, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, errors.New("invalid ids parameter")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) < 2 || len(ids) > 5 {
+		return nil, errors.New("between 2 and 5 course ids are required")
+	}
+
+	return ids, nil
+}
+
 func (app *application) editCourse(w http.ResponseWriter, r *http.Request) {
 
 	r.ParseMultipartForm(0)
@@ -160,7 +235,7 @@ func (app *application) editCourse(w http.ResponseWriter, r *http.Request) {
 	course.ApplicationDeadline = r.FormValue("applicationDeadline")
 	course.CreatedAt = time.Now()
 
-	err := app.models.DB.InsertCourse(course)
+	err := app.db(r).InsertCourse(course)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompareIDs(t *testing.T) {
+	tests := []struct {
+		param string
+		want  []int
+		err   string
+	}{
+		{param: "", err: "between 2 and 5"},
+		{param: "1", err: "between 2 and 5"},
+		{param: "1,2", want: []int{1, 2}},
+		{param: " 3 , 1,,2 ", want: []int{3, 1, 2}},
+		{param: "1,2,3,4,5", want: []int{1, 2, 3, 4, 5}},
+		{param: "1,2,3,4,5,6", err: "between 2 and 5"},
+		{param: "1,1,1", err: "between 2 and 5"},
+		{param: "1,2,1,2,3,3,4,5", want: []int{1, 2, 3, 4, 5}},
+		{param: "1,2,3,1,4,5,6", err: "between 2 and 5"},
+		{param: "1,x", err: "invalid ids parameter"},
+	}
+
+	for _, tt := range tests {
+		got, err := compareIDs(tt.param)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("compareIDs(%q) = %v, %v, want error %q", tt.param, got, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("compareIDs(%q) = %v, %v, want %v", tt.param, got, err, tt.want)
+		}
+	}
+}
+
+func TestCompareCoursesNotFound(t *testing.T) {
+	app := newTestApplication(t)
+	withTestDB(t, app)
+
+	rec := do(t, app.routes(), http.MethodGet, "/v1/courses/compare?ids=2147483646,2147483647", nil)
+	if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), "course 2147483646 not found") {
+		t.Errorf("got %d %s, want 404 naming the course", rec.Code, rec.Body)
+	}
+}
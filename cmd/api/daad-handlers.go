@@ -0,0 +1,139 @@
+package main
+
+import (
+	"backend/daad"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// createDaadSync diffs a snapshot directory against the course table and
+// stores the result for review
+func (app *application) createDaadSync(w http.ResponseWriter, r *http.Request) {
+	r.ParseMultipartForm(0)
+
+	snapshot := r.FormValue("snapshot")
+	if snapshot == "" || snapshot != filepath.Base(snapshot) || strings.HasPrefix(snapshot, ".") {
+		app.errorJSON(w, errors.New("invalid snapshot name"))
+		return
+	}
+
+	programmes, err := daad.LoadSnapshot(filepath.Join(app.config.daad.snapshots, snapshot))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	if len(programmes) == 0 {
+		app.errorJSON(w, errors.New("snapshot contains no programmes"))
+		return
+	}
+
+	courses, err := app.db(r).DaadCourses()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	universities, err := app.db(r).UniversityIndex()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	diff := daad.Compare(programmes, courses, universities)
+
+	id, err := app.db(r).InsertDaadSync(snapshot, diff)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	sync, err := app.db(r).GetDaadSync(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, sync, "sync")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getDaadSyncs(w http.ResponseWriter, r *http.Request) {
+	syncs, err := app.db(r).GetDaadSyncs()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, syncs, "syncs")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getDaadSync(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	sync, err := app.db(r).GetDaadSync(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, sync, "sync")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) applyDaadSync(w http.ResponseWriter, r *http.Request) {
+	app.reviewDaadSync(w, r, app.db(r).ApplyDaadSync)
+}
+
+func (app *application) rejectDaadSync(w http.ResponseWriter, r *http.Request) {
+	app.reviewDaadSync(w, r, app.db(r).RejectDaadSync)
+}
+
+func (app *application) reviewDaadSync(w http.ResponseWriter, r *http.Request, review func(id int, reviewer int) error) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	err = review(id, userID(r))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	sync, err := app.db(r).GetDaadSync(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, sync, "sync")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
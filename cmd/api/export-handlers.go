@@ -0,0 +1,194 @@
+package main
+
+import (
+	"backend/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tableWriter is implemented by the csv and xlsx export formats
+type tableWriter interface {
+	Write(record []string) error
+	Close() error
+}
+
+type csvTableWriter struct {
+	*csv.Writer
+}
+
+// Write escapes the fields spreadsheet programs would run as formulas.
+// Titles and authors come from public submissions.
+func (c csvTableWriter) Write(record []string) error {
+	escaped := make([]string, len(record))
+	for i, field := range record {
+		escaped[i] = escapeFormula(field)
+	}
+	return c.Writer.Write(escaped)
+}
+
+// escapeFormula prefixes fields starting like a formula with ', which makes
+// spreadsheet programs show them as text
+func escapeFormula(field string) string {
+	if field != "" && strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+		return "'" + field
+	}
+	return field
+}
+
+func (c csvTableWriter) Close() error {
+	c.Flush()
+	return c.Error()
+}
+
+// countingWriter remembers whether anything reached the client, so errors
+// before the first byte can still be reported as JSON.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+var courseExportHeader = []string{
+	"id", "university", "city", "course_type", "name_en", "name_en_short", "subject", "languages",
+	"tuition_fees", "beginning", "programme_duration", "application_deadline", "daadlink",
+	"is_tu9", "is_u15", "qs_ranking", "is_elearning", "is_complete_online_possible", "article_count",
+}
+
+var articleExportHeader = []string{
+	"id", "title", "author", "link", "published_at", "source", "course_type",
+	"author_bs_school", "author_bs_school_short", "author_bs_department", "author_bs_gpa",
+	"author_ms_school", "author_ms_school_short", "author_ms_department", "author_ms_gpa",
+	"author_toefl", "author_ielts", "author_gre", "author_gmat", "author_testdaf", "author_goethe",
+}
+
+func (app *application) exportCourses(w http.ResponseWriter, r *http.Request) {
+	cp := courseParams(r)
+
+	app.export(w, r, "courses", courseExportHeader, func(tw tableWriter) error {
+		return app.db(r).EachCourse(cp, func(c *models.Course) error {
+			return tw.Write([]string{
+				strconv.Itoa(c.ID),
+				c.UniversityNameEn,
+				c.City,
+				c.CourseType,
+				c.NameEn,
+				c.NameEnShort,
+				c.Subject,
+				strings.Join(c.CourseLanguage, ", "),
+				c.TuitionFees,
+				c.Beginning,
+				c.ProgrammeDuration,
+				c.ApplicationDeadline,
+				c.Daadlink,
+				strconv.FormatBool(c.IsTu9),
+				strconv.FormatBool(c.IsU15),
+				strconv.Itoa(c.QsRanking),
+				strconv.FormatBool(c.IsElearning),
+				strconv.FormatBool(c.IsCompleteOnlinePossible),
+				strconv.Itoa(c.ArticleCount),
+			})
+		})
+	})
+}
+
+func (app *application) exportArticles(w http.ResponseWriter, r *http.Request) {
+	ap := articleParams(r)
+
+	app.export(w, r, "articles", articleExportHeader, func(tw tableWriter) error {
+		return app.db(r).EachArticle(ap, func(a *models.Article) error {
+			return tw.Write([]string{
+				strconv.Itoa(a.ID),
+				a.Title,
+				a.Author,
+				a.Link,
+				a.PublishedAt.Format("2006-01-02"),
+				a.Source,
+				a.CourseType,
+				a.AuthorBsSchool,
+				a.AuthorBsSchoolShort,
+				a.AuthorBsDepartment,
+				a.AuthorBsGpa,
+				a.AuthorMsSchool,
+				a.AuthorMsSchoolShort,
+				a.AuthorMsDepartment,
+				a.AuthorMsGpa,
+				a.AuthorToefl,
+				a.AuthorIelts,
+				a.AuthorGre,
+				a.AuthorGmat,
+				a.AuthorTestdaf,
+				a.AuthorGoethe,
+			})
+		})
+	})
+}
+
+// export streams the rows produced by fill as csv or xlsx, selected by the format parameter
+func (app *application) export(w http.ResponseWriter, r *http.Request, name string, header []string, fill func(tableWriter) error) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+
+	filename := fmt.Sprintf("go-germany-%s-%s.%s", name, time.Now().Format("2006-01-02"), format)
+	cw := &countingWriter{w: w}
+
+	var tw tableWriter
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		tw = csvTableWriter{csv.NewWriter(cw)}
+		// UTF-8 BOM so spreadsheet programs detect the encoding of Chinese names
+		err := tw.Write(append([]string{"\ufeff" + header[0]}, header[1:]...))
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	case "xlsx":
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		xw, err := newXLSXWriter(cw, name)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+		tw = xw
+		err = tw.Write(header)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	default:
+		app.errorJSON(w, errors.New("format must be csv or xlsx"))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+
+	err := fill(tw)
+	if err != nil {
+		if cw.n == 0 {
+			w.Header().Del("Content-Disposition")
+			app.errorJSON(w, err)
+			return
+		}
+		app.logger.ErrorContext(r.Context(), "export", "name", name, "error", err)
+		return
+	}
+
+	err = tw.Close()
+	if err != nil {
+		app.logger.ErrorContext(r.Context(), "export", "name", name, "error", err)
+	}
+}
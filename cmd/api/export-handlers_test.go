@@ -0,0 +1,68 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+)
+
+var formulaRecord = []string{"=HYPERLINK(\"http://evil\")", "+1", "-2+3", "@SUM(A1)", "\tx", "plain", "", "a=b"}
+
+func TestCSVEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	tw := csvTableWriter{csv.NewWriter(&buf)}
+	if err := tw.Write(formulaRecord); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := csv.NewReader(&buf).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"'=HYPERLINK(\"http://evil\")", "'+1", "'-2+3", "'@SUM(A1)", "'\tx", "plain", "", "a=b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("field %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestXLSXWritesInlineStrings(t *testing.T) {
+	var buf bytes.Buffer
+	xw, err := newXLSXWriter(&buf, "courses")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xw.Write(formulaRecord); err != nil {
+		t.Fatal(err)
+	}
+	if err := xw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(sheet), "<f>") {
+		t.Error("sheet holds a formula")
+	}
+	if n := strings.Count(string(sheet), `<c t="inlineStr">`); n != len(formulaRecord) {
+		t.Errorf("got %d inline string cells, want %d", n, len(formulaRecord))
+	}
+}
@@ -0,0 +1,650 @@
+package main
+
+import (
+	"backend/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const (
+	maxGraphQLDepth      = 8
+	maxGraphQLComplexity = 10000
+	// graphQLListSize is the size assumed for lists without a pageSize argument
+	graphQLListSize    = 20
+	maxGraphQLPageSize = 100
+	maxGraphQLBody     = 1 << 20
+)
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// graphQL executes a query sent as json body or, for GET, as query, variables
+// and operationName parameters
+func (app *application) graphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			err := json.Unmarshal([]byte(v), &req.Variables)
+			if err != nil {
+				app.errorJSON(w, errors.New("variables must be a json object"))
+				return
+			}
+		}
+	} else {
+		r.Body = http.MaxBytesReader(w, r.Body, maxGraphQLBody)
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.errorJSON(w, errors.New("invalid request body"))
+			return
+		}
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		app.errorJSON(w, errors.New("query is required"))
+		return
+	}
+
+	status := http.StatusOK
+	var result *graphql.Result
+
+	err := checkGraphQLLimits(app.graphQLSchema, req)
+	if err != nil {
+		status = http.StatusBadRequest
+		result = &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())}}
+	} else {
+		ctx := context.WithValue(r.Context(), graphQLLoadersKey, newGraphQLLoaders(app.db(r), app.filters))
+		result = graphql.Do(graphql.Params{
+			Schema:         app.graphQLSchema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+			Context:        ctx,
+		})
+	}
+
+	js, err := json.Marshal(result)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(js)
+}
+
+// newGraphQLSchema builds the read only schema over courses, universities and articles
+func newGraphQLSchema() (graphql.Schema, error) {
+	str := func(description string) *graphql.Field {
+		return &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: description}
+	}
+	boolean := func() *graphql.Field {
+		return &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)}
+	}
+	integer := func() *graphql.Field {
+		return &graphql.Field{Type: graphql.NewNonNull(graphql.Int)}
+	}
+
+	language := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Language",
+		Fields: graphql.Fields{
+			"id":            integer(),
+			"language_name": str(""),
+		},
+	})
+
+	pagination := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Pagination",
+		Description: "The values of the Pagination header of the REST listings",
+		Fields: graphql.Fields{
+			"current_page": integer(),
+			"total_pages":  integer(),
+			"page_size":    integer(),
+			"total_count":  integer(),
+		},
+	})
+
+	var course, university, article *graphql.Object
+
+	university = graphql.NewObject(graphql.ObjectConfig{
+		Name: "University",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":           integer(),
+				"name_en":      str(""),
+				"city":         str(""),
+				"is_from_daad": boolean(),
+				"is_tu9":       boolean(),
+				"is_u15":       boolean(),
+				"qs_ranking":   integer(),
+				"link":         str(""),
+				"courses": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(course))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).universityCourses.load(p.Source.(*models.University).ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	articleResult := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ArticleResult",
+		Description: "The result an article reports for a course",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"result":      str("Admission or Rejection"),
+				"is_decision": boolean(),
+				"article": &graphql.Field{
+					Type: article,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).articles.load(p.Source.(models.CourseArticle).ArticleID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	courseResult := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CourseResult",
+		Description: "The result an article reports for a course",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"result":      str("Admission or Rejection"),
+				"is_decision": boolean(),
+				"course": &graphql.Field{
+					Type: course,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).courses.load(p.Source.(models.ArticleCourse).CourseID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	course = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Course",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                          integer(),
+				"course_type":                 str(""),
+				"name_en":                     str(""),
+				"name_en_short":               str(""),
+				"tuition_fees":                str(""),
+				"beginning":                   str(""),
+				"subject":                     str(""),
+				"daadlink":                    str(""),
+				"is_elearning":                boolean(),
+				"application_deadline":        str(""),
+				"is_complete_online_possible": boolean(),
+				"programme_duration":          str(""),
+				"is_from_daad":                boolean(),
+				"university": &graphql.Field{
+					Type: university,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).university(p.Source.(*models.Course)), nil
+					},
+				},
+				"languages": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(language))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).courseLanguages.load(p.Source.(*models.Course).ID), nil
+					},
+				},
+				"articles": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(articleResult))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).courseArticles.load(p.Source.(*models.Course).ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	article = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Article",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                     integer(),
+				"title":                  str(""),
+				"author":                 str(""),
+				"link":                   str(""),
+				"published_at":           &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"source":                 str(""),
+				"author_bs_school":       str(""),
+				"author_bs_school_short": str(""),
+				"author_bs_department":   str(""),
+				"author_bs_gpa":          str(""),
+				"author_ms_school":       str(""),
+				"author_ms_school_short": str(""),
+				"author_ms_department":   str(""),
+				"author_ms_gpa":          str(""),
+				"author_toefl":           str(""),
+				"author_ielts":           str(""),
+				"author_gre":             str(""),
+				"author_gmat":            str(""),
+				"author_testdaf":         str(""),
+				"author_goethe":          str(""),
+				"course_type":            str(""),
+				"content":                str(""),
+				"courses": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(courseResult))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).articleCourses.load(p.Source.(*models.Article).ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	stringLists := func(names ...string) graphql.Fields {
+		fields := graphql.Fields{}
+		for _, n := range names {
+			fields[n] = &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))}
+		}
+		return fields
+	}
+
+	courseFilters := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "CourseFilters",
+		Fields: stringLists("course_types", "languages", "subjects", "institutions"),
+	})
+
+	articleFilters := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "ArticleFilters",
+		Fields: stringLists("sources", "bs_schools", "bs_departments", "ms_schools", "ms_departments", "course_types"),
+	})
+
+	page := func(name string, item *graphql.Object) *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				"items":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+				"pagination": &graphql.Field{Type: graphql.NewNonNull(pagination)},
+			},
+		})
+	}
+
+	paging := graphql.FieldConfigArgument{
+		"pageNumber": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"pageSize":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	}
+	args := func(strs []string, bools []string) graphql.FieldConfigArgument {
+		a := graphql.FieldConfigArgument{}
+		for k, v := range paging {
+			a[k] = v
+		}
+		for _, s := range strs {
+			a[s] = &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""}
+		}
+		for _, b := range bools {
+			a[b] = &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false}
+		}
+		return a
+	}
+	byID := graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"course": &graphql.Field{
+				Type: course,
+				Args: byID,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).courses.load(p.Args["id"].(int)), nil
+				},
+			},
+			"courses": &graphql.Field{
+				Type:        graphql.NewNonNull(page("CoursePage", course)),
+				Description: "Filtered page of courses, with the filters of GET /v1/courses",
+				Args:        args([]string{"searchTerm", "courseTypes", "languages", "subjects", "institutions", "orderBy"}, []string{"isTu9", "isU15", "hasArticles"}),
+				Resolve:     resolveCourses,
+			},
+			"article": &graphql.Field{
+				Type: article,
+				Args: byID,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).articles.load(p.Args["id"].(int)), nil
+				},
+			},
+			"articles": &graphql.Field{
+				Type:        graphql.NewNonNull(page("ArticlePage", article)),
+				Description: "Filtered page of articles, with the filters of GET /v1/articles",
+				Args:        args([]string{"searchTerm", "sources", "bsSchools", "bsDepartments", "msSchools", "msDepartments", "courseType"}, nil),
+				Resolve:     resolveArticles,
+			},
+			"university": &graphql.Field{
+				Type: university,
+				Args: byID,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).universities.load(p.Args["id"].(int)), nil
+				},
+			},
+			"universities": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(university))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).db.Universities()
+				},
+			},
+			"languages": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(language))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).db.Languages()
+				},
+			},
+			"courseFilters": &graphql.Field{
+				Type: graphql.NewNonNull(courseFilters),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					l := loadersFrom(p.Context)
+					return l.filters.courseFilters(l.db)
+				},
+			},
+			"articleFilters": &graphql.Field{
+				Type: graphql.NewNonNull(articleFilters),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					l := loadersFrom(p.Context)
+					return l.filters.articleFilters(l.db)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+type graphQLPage struct {
+	Items      interface{} `json:"items"`
+	Pagination MetaData    `json:"pagination"`
+}
+
+func pageArgs(p graphql.ResolveParams) (int, int, error) {
+	pn, ps := p.Args["pageNumber"].(int), p.Args["pageSize"].(int)
+	if pn < 1 || ps < 1 {
+		return 0, 0, errors.New("pageNumber and pageSize must be positive")
+	}
+	if ps > maxGraphQLPageSize {
+		return 0, 0, fmt.Errorf("pageSize must be at most %d", maxGraphQLPageSize)
+	}
+	return pn, ps, nil
+}
+
+func newGraphQLPage(items interface{}, pn, ps, count int) graphQLPage {
+	return graphQLPage{
+		Items: items,
+		Pagination: MetaData{
+			CurrentPage: pn,
+			PageSize:    ps,
+			TotalCount:  count,
+			TotalPages:  int(math.Ceil(float64(count) / float64(ps))),
+		},
+	}
+}
+
+// resolveCourses lists courses like getAllCourses. Languages and articles are
+// left to the loaders.
+func resolveCourses(p graphql.ResolveParams) (interface{}, error) {
+	pn, ps, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := func(name string) string {
+		s, _ := p.Args[name].(string)
+		return strings.ToLower(s)
+	}
+	flag := func(name string) bool {
+		b, _ := p.Args[name].(bool)
+		return b
+	}
+
+	cp := models.CourseParams{
+		PageNumber:           pn,
+		PageSize:             ps,
+		SearchTerm:           arg("searchTerm"),
+		CourseTypes:          arg("courseTypes"),
+		Languages:            arg("languages"),
+		Subjects:             arg("subjects"),
+		Institutions:         arg("institutions"),
+		IsTu9:                flag("isTu9"),
+		IsU15:                flag("isU15"),
+		HasArticles:          flag("hasArticles"),
+		OrderBy:              arg("orderBy"),
+		HideLanguageNArticle: true,
+	}
+
+	l := loadersFrom(p.Context)
+	courses, count, err := l.db.All(cp)
+	if err != nil {
+		return nil, err
+	}
+	l.primeCourses(courses)
+
+	return newGraphQLPage(courses, pn, ps, count), nil
+}
+
+// resolveArticles lists articles like getAllArticles. The courses are left
+// to the loaders.
+func resolveArticles(p graphql.ResolveParams) (interface{}, error) {
+	pn, ps, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := func(name string) string {
+		s, _ := p.Args[name].(string)
+		return strings.ToLower(s)
+	}
+
+	courseType, _ := p.Args["courseType"].(string)
+
+	ap := models.ArticleParams{
+		PageNumber:      pn,
+		PageSize:        ps,
+		SearchTerm:      arg("searchTerm"),
+		Sources:         arg("sources"),
+		BsSchools:       arg("bsSchools"),
+		BsDepartments:   arg("bsDepartments"),
+		MsSchools:       arg("msSchools"),
+		MsDepartments:   arg("msDepartments"),
+		CourseType:      courseType,
+		HideApplication: true,
+	}
+
+	l := loadersFrom(p.Context)
+	articles, count, err := l.db.GetArticles(ap)
+	if err != nil {
+		return nil, err
+	}
+	l.primeArticles(articles)
+
+	return newGraphQLPage(articles, pn, ps, count), nil
+}
+
+// checkGraphQLLimits rejects queries nested deeper than maxGraphQLDepth or
+// whose estimated number of resolved fields exceeds maxGraphQLComplexity.
+// Lists count pageSize times, or graphQLListSize without that argument.
+// Queries whose pageSize cannot be told, like a variable without a value or
+// default, are rejected. Introspection fields are not counted. Queries that
+// do not parse are left to the executor to report.
+func checkGraphQLLimits(schema graphql.Schema, req graphQLRequest) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return nil
+	}
+
+	c := &queryCost{
+		fragments: make(map[string]*ast.FragmentDefinition),
+	}
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if req.OperationName == "" || (def.Name != nil && def.Name.Value == req.OperationName) {
+				operations = append(operations, def)
+			}
+		}
+	}
+
+	for _, op := range operations {
+		if op.Operation != ast.OperationTypeQuery {
+			continue
+		}
+		c.variables = operationVariables(op, req.Variables)
+		cost, depth := c.selections(op.SelectionSet, schema.QueryType(), 0, graphQLListSize, map[string]bool{})
+		if c.err != nil {
+			return c.err
+		}
+		if depth > maxGraphQLDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, maxGraphQLDepth)
+		}
+		if cost > maxGraphQLComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", cost, maxGraphQLComplexity)
+		}
+	}
+
+	return nil
+}
+
+type queryCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]ast.Value
+	err       error
+}
+
+// operationVariables returns the values of the variables of op as ast values,
+// falling back to the defaults of its variable definitions
+func operationVariables(op *ast.OperationDefinition, values map[string]interface{}) map[string]ast.Value {
+	vars := make(map[string]ast.Value)
+	for _, def := range op.VariableDefinitions {
+		name := def.Variable.Name.Value
+		if v, ok := values[name]; ok {
+			// json numbers decode as float64
+			if n, ok := v.(float64); ok && n == math.Trunc(n) {
+				vars[name] = &ast.IntValue{Value: strconv.FormatInt(int64(n), 10)}
+			}
+			continue
+		}
+		if def.DefaultValue != nil {
+			vars[name] = def.DefaultValue
+		}
+	}
+	return vars
+}
+
+// selections returns the cost and depth of a selection set on parent, where
+// lists are assumed to hold size items
+func (c *queryCost) selections(set *ast.SelectionSet, parent *graphql.Object, depth, size int, spread map[string]bool) (int, int) {
+	if set == nil {
+		return 0, depth
+	}
+
+	cost, maxDepth := 0, depth
+	add := func(cst, d int) {
+		cost += cst
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+
+	for _, sel := range set.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			name := sel.Name.Value
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+			def, ok := parent.Fields()[name]
+			if !ok {
+				continue
+			}
+
+			t, list := def.Type, false
+			for {
+				if nn, ok := t.(*graphql.NonNull); ok {
+					t = nn.OfType
+				} else if l, ok := t.(*graphql.List); ok {
+					t, list = l.OfType, true
+				} else {
+					break
+				}
+			}
+
+			childSize, err := c.pageSize(sel)
+			if err != nil {
+				if c.err == nil {
+					c.err = err
+				}
+				continue
+			}
+
+			child, d := 0, depth+1
+			if obj, ok := t.(*graphql.Object); ok {
+				child, d = c.selections(sel.SelectionSet, obj, depth+1, childSize, spread)
+			}
+			if list {
+				child *= size
+			}
+			add(1+child, d)
+
+		case *ast.InlineFragment:
+			add(c.selections(sel.SelectionSet, parent, depth, size, spread))
+
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag, ok := c.fragments[name]
+			if !ok || spread[name] {
+				continue
+			}
+			spread[name] = true
+			add(c.selections(frag.SelectionSet, parent, depth, size, spread))
+			delete(spread, name)
+		}
+	}
+
+	return cost, maxDepth
+}
+
+// pageSize returns the pageSize argument of a listing field, which sizes the
+// items list below it, or graphQLListSize for fields without that argument
+func (c *queryCost) pageSize(field *ast.Field) (int, error) {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "pageSize" {
+			continue
+		}
+
+		value := arg.Value
+		if v, ok := value.(*ast.Variable); ok {
+			value = c.variables[v.Name.Value]
+		}
+		if v, ok := value.(*ast.IntValue); ok {
+			n, err := strconv.Atoi(v.Value)
+			if err == nil && n > 0 {
+				return n, nil
+			}
+		}
+		return 0, fmt.Errorf("pageSize of %s must be a positive integer", field.Name.Value)
+	}
+	return graphQLListSize, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckGraphQLLimits(t *testing.T) {
+	schema, err := newGraphQLSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const variableQuery = `query($n: Int) { courses(pageNumber: 1, pageSize: $n) { items { id name_en } } }`
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      string
+	}{
+		{name: "small page", query: `{ courses(pageNumber: 1, pageSize: 20) { items { id name_en } } }`},
+		{name: "large literal", query: `{ courses(pageNumber: 1, pageSize: 100000) { items { id name_en } } }`, want: "complexity"},
+		{name: "small variable", query: variableQuery, variables: map[string]interface{}{"n": float64(20)}},
+		{name: "large variable", query: variableQuery, variables: map[string]interface{}{"n": float64(100000)}, want: "complexity"},
+		{name: "large default", query: `query($n: Int = 100000) { courses(pageNumber: 1, pageSize: $n) { items { id name_en } } }`, want: "complexity"},
+		{name: "default overridden", query: `query($n: Int = 100000) { courses(pageNumber: 1, pageSize: $n) { items { id name_en } } }`,
+			variables: map[string]interface{}{"n": float64(20)}},
+		{name: "variable without value", query: variableQuery, want: "pageSize of courses must be a positive integer"},
+		{name: "string variable", query: variableQuery, variables: map[string]interface{}{"n": "100000"}, want: "pageSize of courses must be a positive integer"},
+		{name: "fragment", query: `query($n: Int = 100000) { ...page } fragment page on Query { courses(pageNumber: 1, pageSize: $n) { items { id } } }`, want: "complexity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkGraphQLLimits(schema, graphQLRequest{Query: tt.query, Variables: tt.variables})
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("got %v, want the query accepted", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"backend/catalogue"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serveGRPC serves the catalogue service on the grpc port until ctx is done,
+// then lets the running calls finish until expired is closed. It listens on
+// all interfaces only in production and with a TLS certificate.
+func (app *application) serveGRPC(ctx context.Context, expired <-chan struct{}) {
+	host := "127.0.0.1"
+	var opts []grpc.ServerOption
+
+	if app.config.grpcTLS.cert != "" {
+		creds, err := credentials.NewServerTLSFromFile(app.config.grpcTLS.cert, app.config.grpcTLS.key)
+		if err != nil {
+			app.logger.Error("grpc", "error", err)
+			return
+		}
+		opts = append(opts, grpc.Creds(creds))
+		if app.config.env == "production" {
+			host = ""
+		}
+	} else if app.config.env == "production" {
+		app.logger.Warn("grpc has no tls certificate, listening on localhost only")
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", host, app.config.grpcPort))
+	if err != nil {
+		app.logger.Error("grpc", "error", err)
+		return
+	}
+
+	srv := app.grpcServer(opts...)
+	go stopGRPC(ctx, expired, srv)
+
+	app.logger.Info("starting grpc server", "port", app.config.grpcPort, "tls", len(opts) > 0)
+	err = srv.Serve(lis)
+	if err != nil {
+		app.logger.Error("grpc", "error", err)
+	}
+}
+
+// grpcServer returns a server of the catalogue service whose calls are
+// counted against the api key in the x-api-key metadata
+func (app *application) grpcServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			err := app.grpcAPIKey(ctx, info.FullMethod, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := app.grpcAPIKey(ss.Context(), info.FullMethod, ss.SetHeader)
+			if err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	)
+
+	srv := grpc.NewServer(opts...)
+	catalogue.RegisterCatalogueServer(srv, catalogue.NewServer(&app.models.DB, app.logger))
+	return srv
+}
+
+// grpcAPIKey applies the api key limits of the REST routes to a call of
+// method and sends the rate limit and quota headers as metadata
+func (app *application) grpcAPIKey(ctx context.Context, method string, setHeader func(metadata.MD) error) error {
+	var key string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("x-api-key"); len(v) > 0 {
+		key = v[0]
+	}
+
+	h := http.Header{}
+	code, err := app.useAPIKey(app.models.DB.WithContext(ctx), key, method, h)
+
+	header := metadata.MD{}
+	for k, v := range h {
+		header.Set(k, v...)
+	}
+	if len(header) > 0 {
+		setHeader(header)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	switch code {
+	case http.StatusUnauthorized:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case http.StatusForbidden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case http.StatusTooManyRequests:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		app.logger.ErrorContext(ctx, "grpc", "method", method, "error", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// stopGRPC lets the running calls of srv finish once ctx is done and cancels
+// those still running when expired is closed, as streams may never end
+func stopGRPC(ctx context.Context, expired <-chan struct{}, srv *grpc.Server) {
+	<-ctx.Done()
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-expired:
+			srv.Stop()
+		case <-stopped:
+		}
+	}()
+
+	srv.GracefulStop()
+}
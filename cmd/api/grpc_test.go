@@ -0,0 +1,295 @@
+package main
+
+import (
+	"backend/catalogue"
+	"backend/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGRPC serves srv in memory and returns a connection to it
+func dialGRPC(t *testing.T, srv *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestGRPCAPIKey(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.apiKeysRequired = true
+
+	connector, err := models.NewConnector("postgres://nobody@127.0.0.1:1/none?sslmode=disable&connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+	app.models = models.NewModels(db)
+
+	client := catalogue.NewCatalogueClient(dialGRPC(t, app.grpcServer()))
+	req := &catalogue.CourseParams{PageNumber: 1, PageSize: 5}
+
+	tests := []struct {
+		name string
+		key  string
+		http int
+		grpc codes.Code
+	}{
+		{name: "missing key", http: http.StatusUnauthorized, grpc: codes.Unauthenticated},
+		{name: "database error", key: "some key", http: http.StatusInternalServerError, grpc: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/v1/courses?pageNumber=1&pageSize=5", nil)
+			if tt.key != "" {
+				r.Header.Set("X-API-Key", tt.key)
+			}
+			rec := httptest.NewRecorder()
+			app.routes().ServeHTTP(rec, r)
+			if rec.Code != tt.http {
+				t.Errorf("rest: got status %d, want %d", rec.Code, tt.http)
+			}
+
+			ctx := context.Background()
+			if tt.key != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", tt.key)
+			}
+
+			_, err := client.ListCourses(ctx, req)
+			if status.Code(err) != tt.grpc {
+				t.Errorf("unary: got %v, want %s", err, tt.grpc)
+			}
+
+			stream, err := client.StreamCourses(ctx, req)
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			if status.Code(err) != tt.grpc {
+				t.Errorf("stream: got %v, want %s", err, tt.grpc)
+			}
+		})
+	}
+}
+
+func TestGRPCHidesErrors(t *testing.T) {
+	app := newTestApplication(t)
+
+	connector, err := models.NewConnector("postgres://nobody@127.0.0.1:1/none?sslmode=disable&connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+	app.models = models.NewModels(db)
+
+	client := catalogue.NewCatalogueClient(dialGRPC(t, app.grpcServer()))
+	ctx := context.Background()
+	req := &catalogue.CourseParams{PageNumber: 1, PageSize: 5}
+
+	_, unary := client.ListCourses(ctx, req)
+	_, get := client.GetCourse(ctx, &catalogue.GetCourseRequest{Id: 1})
+	stream, err := client.StreamCourses(ctx, req)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+
+	for name, err := range map[string]error{"unary": unary, "get": get, "stream": err} {
+		st, _ := status.FromError(err)
+		if st.Code() != codes.Internal || st.Message() != "internal server error" {
+			t.Errorf("%s: got %v, want a generic internal error", name, err)
+		}
+	}
+
+	// the api key lookup fails on the database as well
+	app.config.apiKeysRequired = true
+	_, err = client.ListCourses(metadata.AppendToOutgoingContext(ctx, "x-api-key", "some key"), req)
+	st, _ := status.FromError(err)
+	if st.Code() != codes.Internal || st.Message() != "internal server error" {
+		t.Errorf("api key: got %v, want a generic internal error", err)
+	}
+}
+
+func TestStopGRPC(t *testing.T) {
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, grpchealth.NewServer())
+	client := healthpb.NewHealthClient(dialGRPC(t, srv))
+
+	// a watch stays open until the client or the server ends it
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	expired := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		stopGRPC(ctx, expired, srv)
+		close(stopped)
+	}()
+
+	cancel()
+	select {
+	case <-stopped:
+		t.Fatal("stopped before the open stream ended or the timeout expired")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(expired)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("still running after the timeout expired")
+	}
+
+	if _, err := stream.Recv(); err == nil {
+		t.Error("stream is still open")
+	}
+}
+
+func TestStopGRPCWithoutCalls(t *testing.T) {
+	srv := grpc.NewServer()
+	dialGRPC(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		stopGRPC(ctx, make(chan struct{}), srv)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waited for the timeout without running calls")
+	}
+}
+
+func TestGRPCMatchesREST(t *testing.T) {
+	app := newTestApplication(t)
+	withTestDB(t, app)
+
+	client := catalogue.NewCatalogueClient(dialGRPC(t, app.grpcServer()))
+	h := app.routes()
+
+	tests := []*catalogue.CourseParams{
+		{PageNumber: 1, PageSize: 5},
+		{PageNumber: 2, PageSize: 3, OrderBy: "name_en"},
+		{PageNumber: 1, PageSize: 10, CourseTypes: "1", IsTu9: true},
+		{PageNumber: 1, PageSize: 10, SearchTerm: "engineering"},
+	}
+
+	for _, req := range tests {
+		q := url.Values{}
+		q.Set("pageNumber", strconv.Itoa(int(req.PageNumber)))
+		q.Set("pageSize", strconv.Itoa(int(req.PageSize)))
+		q.Set("orderBy", req.OrderBy)
+		q.Set("courseTypes", req.CourseTypes)
+		q.Set("searchTerm", req.SearchTerm)
+		q.Set("isTu9", strconv.FormatBool(req.IsTu9))
+
+		t.Run(q.Encode(), func(t *testing.T) {
+			rec := do(t, h, http.MethodGet, "/v1/courses?"+q.Encode(), nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("rest: got status %d: %s", rec.Code, rec.Body)
+			}
+
+			var rest struct {
+				Courses []*models.Course `json:"courses"`
+			}
+			err := json.Unmarshal(rec.Body.Bytes(), &rest)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var md MetaData
+			err = json.Unmarshal([]byte(rec.Header().Get("Pagination")), &md)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			page, err := client.ListCourses(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if int(page.Pagination.TotalCount) != md.TotalCount || int(page.Pagination.TotalPages) != md.TotalPages {
+				t.Errorf("got %d courses on %d pages over grpc, %d on %d over rest",
+					page.Pagination.TotalCount, page.Pagination.TotalPages, md.TotalCount, md.TotalPages)
+			}
+
+			if len(page.Courses) != len(rest.Courses) {
+				t.Fatalf("got %d courses over grpc, %d over rest", len(page.Courses), len(rest.Courses))
+			}
+			for i, c := range page.Courses {
+				want := rest.Courses[i]
+				if int(c.Id) != want.ID || c.NameEn != want.NameEn || c.CourseType != want.CourseType || c.University.NameEn != want.UniversityNameEn {
+					t.Errorf("course %d: got %d %q over grpc, %d %q over rest", i, c.Id, c.NameEn, want.ID, want.NameEn)
+				}
+			}
+
+			if len(rest.Courses) == 0 {
+				return
+			}
+
+			id := rest.Courses[0].ID
+			rec = do(t, h, http.MethodGet, "/v1/course/"+strconv.Itoa(id), nil)
+			var one struct {
+				Course *models.Course `json:"course"`
+			}
+			err = json.Unmarshal(rec.Body.Bytes(), &one)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := client.GetCourse(context.Background(), &catalogue.GetCourseRequest{Id: int32(id)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.NameEn != one.Course.NameEn || c.Daadlink != one.Course.Daadlink || c.TuitionFees != one.Course.TuitionFees {
+				t.Errorf("course %d differs between grpc and rest", id)
+			}
+		})
+	}
+
+	_, err := client.GetCourse(context.Background(), &catalogue.GetCourseRequest{Id: -1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got %v for a missing course, want NotFound", err)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// healthCheck is the outcome of one readiness check
+type healthCheck struct {
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Detail     string  `json:"detail,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type healthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]healthCheck `json:"checks,omitempty"`
+}
+
+// health holds the state the readiness checks compare against
+type health struct {
+	draining  atomic.Bool
+	waitCount atomic.Int64
+}
+
+// getLivez reports that the process is up. It does not look at the database,
+// so a database outage does not get every instance restarted.
+func (app *application) getLivez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	err := app.writeJSON(w, http.StatusOK, healthReport{Status: "ok"}, "liveness")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// getReadyz runs the readiness checks and answers 503 when any of them fails
+func (app *application) getReadyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	ctx, cancel := context.WithTimeout(r.Context(), app.config.readyTimeout)
+	defer cancel()
+
+	report := healthReport{
+		Status: "ok",
+		Checks: map[string]healthCheck{
+			"draining": runCheck(app.checkDraining),
+			"database": runCheck(func() (string, error) { return "", app.models.DB.Ping(ctx) }),
+			"schema":   runCheck(func() (string, error) { return app.checkSchema(ctx) }),
+			"pool":     runCheck(app.checkPool),
+		},
+	}
+
+	status := http.StatusOK
+	for _, c := range report.Checks {
+		if c.Status != "ok" {
+			report.Status = "fail"
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	err := app.writeJSON(w, status, report, "readiness")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func runCheck(check func() (string, error)) healthCheck {
+	start := time.Now()
+	detail, err := check()
+
+	c := healthCheck{
+		Status:     "ok",
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		Detail:     detail,
+	}
+	if err != nil {
+		c.Status = "fail"
+		c.Error = err.Error()
+	}
+	return c
+}
+
+func (app *application) checkDraining() (string, error) {
+	if app.health.draining.Load() {
+		return "", errors.New("shutting down")
+	}
+	return "", nil
+}
+
+// checkSchema fails while migrations the code expects are not applied yet.
+// A newer schema passes, since it is rolled out before the code using it.
+func (app *application) checkSchema(ctx context.Context) (string, error) {
+	want, err := models.LatestMigration()
+	if err != nil {
+		return "", err
+	}
+
+	got, err := app.models.DB.SchemaVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	detail := fmt.Sprintf("version %d, code expects %d", got, want)
+	if got < want {
+		return detail, errors.New("migrations are pending")
+	}
+	return detail, nil
+}
+
+// checkPool fails when every connection is in use and requests had to wait
+// for one since the previous check
+func (app *application) checkPool() (string, error) {
+	stats := app.models.DB.DB.Stats()
+	waited := stats.WaitCount - app.health.waitCount.Swap(stats.WaitCount)
+
+	detail := fmt.Sprintf("%d of %d connections in use, %d waits since the last check", stats.InUse, stats.MaxOpenConnections, waited)
+	if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections && waited > 0 {
+		return detail, errors.New("connection pool is saturated")
+	}
+	return detail, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"backend/mailer"
+	"backend/models"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// newTestApplication returns an application without a database, sending
+// account mails to memory
+func newTestApplication(t *testing.T) *application {
+	t.Helper()
+
+	keys, err := loadSigningKeys("", "", nil, "test secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := newGraphQLSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &application{
+		logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		mailer:            mailer.NewMemory(),
+		keys:              keys,
+		signInGuard:       newSignInGuard(),
+		apiKeyBuckets:     newTokenBuckets(),
+		submissionBuckets: newTokenBuckets(),
+		graphQLSchema:     schema,
+		cache:             newResponseCache(100),
+		filters:           &catalogueFilters{},
+		metrics:           newMetrics(),
+	}
+	app.config.env = "development"
+	app.config.readyTimeout = time.Second
+	app.config.jwt.accessTTL = 15 * time.Minute
+	app.config.jwt.refreshTTL = time.Hour
+	app.config.mail.appURL = "http://localhost:3000"
+
+	return app
+}
+
+// withTestDB connects app to the database of TEST_DATABASE_URL, which must
+// hold the schema with every migration applied. The test is skipped when
+// the variable is not set.
+func withTestDB(t *testing.T, app *application) {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	app.config.db.dsn = dsn
+	db, err := openDB(app.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	app.models = models.NewModels(db)
+}
+
+// do sends a request with body encoded as JSON through h
+func do(t *testing.T, h http.Handler, method, target string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req := httptest.NewRequest(method, target, r)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
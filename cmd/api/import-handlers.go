@@ -0,0 +1,41 @@
+package main
+
+import (
+	"backend/importer"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func (app *application) importRows(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	err := r.ParseMultipartForm(32 << 20)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		app.errorJSON(w, errors.New("a csv file is required"))
+		return
+	}
+	defer file.Close()
+
+	dryRun, _ := strconv.ParseBool(r.FormValue("dryRun"))
+
+	report, err := importer.Run(app.db(r), params.ByName("kind"), file, dryRun)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, report, "report")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"backend/ingest"
+	"backend/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// approvedResp is written when a draft or submission becomes content
+type approvedResp struct {
+	OK        bool `json:"ok"`
+	ContentID int  `json:"content_id"`
+}
+
+// ingestDraft parses an uploaded saved page into a draft for review
+func (app *application) ingestDraft(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(16 << 20)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	file, _, err := r.FormFile("page")
+	if err != nil {
+		app.errorJSON(w, errors.New("a saved html page is required"))
+		return
+	}
+	defer file.Close()
+
+	draft, err := ingest.Parse(file, r.FormValue("link"), r.FormValue("source"))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	links, err := app.db(r).ContentLinks()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	for id, link := range links {
+		if ingest.Canonical(link) == draft.CanonicalLink {
+			app.errorJSON(w, fmt.Errorf("article already exists as content %d", id), http.StatusConflict)
+			return
+		}
+	}
+
+	id, err := app.db(r).InsertDraft(*draft)
+	if errors.Is(err, models.ErrDuplicateDraft) {
+		app.errorJSON(w, err, http.StatusConflict)
+		return
+	}
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	draft, err = app.db(r).GetDraft(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, draft, "draft")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getDrafts(w http.ResponseWriter, r *http.Request) {
+	drafts, err := app.db(r).GetDrafts(r.URL.Query().Get("status"))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, drafts, "drafts")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getDraft(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	draft, err := app.db(r).GetDraft(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, draft, "draft")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// approveDraft takes the same form as editContent, empty fields are taken
+// from the draft and an empty id takes the next free content id
+func (app *application) approveDraft(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	r.ParseMultipartForm(0)
+
+	draft, err := app.db(r).GetDraft(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	c := contentFromForm(r)
+	if c.Link == "" {
+		c.Link = draft.Link
+	}
+	if c.Title == "" {
+		c.Title = draft.Title
+	}
+	if c.Author == "" {
+		c.Author = draft.Author
+	}
+	if c.PublishedAt.IsZero() && draft.PublishedAt != nil {
+		c.PublishedAt = *draft.PublishedAt
+	}
+	if c.Source == "" {
+		c.Source = draft.Source
+	}
+	if c.Content == "" {
+		c.Content = draft.Content
+	}
+
+	contentID, err := app.db(r).ApproveDraft(id, c, userID(r))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, approvedResp{OK: true, ContentID: contentID}, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) rejectDraft(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	err = app.db(r).RejectDraft(id, userID(r))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pascaldekloe/jwt"
+)
+
+// signingKeys signs access tokens with the active key and checks tokens
+// against every loaded key. Keys live in a directory as <kid>.pem files
+// holding an Ed25519 or RSA private key, or only the public key of a key that
+// is being retired. Retired kids are not loaded, so their tokens are rejected.
+type signingKeys struct {
+	register  jwt.KeyRegister
+	activeKid string
+	active    crypto.Signer
+	secret    []byte
+	jwks      []byte
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// loadSigningKeys reads the keys in dir. Without a directory tokens are signed
+// with the HMAC secret. Next to a key directory HS256 tokens are only accepted
+// when acceptSecret is set, which is meant for the switch to asymmetric keys
+// so it logs nobody out. Leaving it set lets the secret mint tokens forever.
+func loadSigningKeys(dir, activeKid string, retired []string, secret string, acceptSecret bool) (*signingKeys, error) {
+	keys := &signingKeys{activeKid: activeKid}
+
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: []jwk{}}
+
+	if dir == "" {
+		if secret == "" {
+			return nil, errors.New("either a jwt secret or a jwt key directory is required")
+		}
+		keys.secret = []byte(secret)
+		keys.register.Secrets = append(keys.register.Secrets, keys.secret)
+		keys.jwks, _ = json.Marshal(set)
+		return keys, nil
+	}
+
+	if acceptSecret {
+		if secret == "" {
+			return nil, errors.New("accepting HS256 tokens requires a jwt secret")
+		}
+		keys.register.Secrets = append(keys.register.Secrets, []byte(secret))
+	}
+
+	isRetired := make(map[string]bool)
+	for _, kid := range retired {
+		isRetired[strings.TrimSpace(kid)] = true
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+		if isRetired[kid] {
+			continue
+		}
+
+		key, err := readKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", kid, err)
+		}
+
+		var public crypto.PublicKey = key
+		if signer, ok := key.(crypto.Signer); ok {
+			public = signer.Public()
+			if kid == activeKid {
+				keys.active = signer
+			}
+		}
+
+		switch public := public.(type) {
+		case ed25519.PublicKey:
+			keys.register.EdDSAs = append(keys.register.EdDSAs, public)
+			keys.register.EdDSAIDs = append(keys.register.EdDSAIDs, kid)
+			set.Keys = append(set.Keys, jwk{
+				Kty: "OKP",
+				Kid: kid,
+				Use: "sig",
+				Alg: jwt.EdDSA,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		case *rsa.PublicKey:
+			keys.register.RSAs = append(keys.register.RSAs, public)
+			keys.register.RSAIDs = append(keys.register.RSAIDs, kid)
+			set.Keys = append(set.Keys, jwk{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				Alg: jwt.RS256,
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		default:
+			return nil, fmt.Errorf("jwt key %s: unsupported key type %T", kid, public)
+		}
+	}
+
+	if keys.active == nil {
+		return nil, fmt.Errorf("active jwt key %q has no private key in %s", activeKid, dir)
+	}
+
+	keys.jwks, err = json.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func readKey(file string) (interface{}, error) {
+	text, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(text)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM type %q", block.Type)
+	}
+}
+
+// sign signs the claims with the active key, or the HMAC secret without one
+func (keys *signingKeys) sign(claims *jwt.Claims) ([]byte, error) {
+	switch key := keys.active.(type) {
+	case ed25519.PrivateKey:
+		claims.KeyID = keys.activeKid
+		return claims.EdDSASign(key)
+	case *rsa.PrivateKey:
+		claims.KeyID = keys.activeKid
+		return claims.RSASign(jwt.RS256, key)
+	case nil:
+		return claims.HMACSign(jwt.HS256, keys.secret)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// check verifies the signature of a token against every accepted key
+func (keys *signingKeys) check(token []byte) (*jwt.Claims, error) {
+	return keys.register.Check(token)
+}
+
+// getJWKS publishes the public keys tokens may be signed with
+func (app *application) getJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/jwk-set+json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(app.keys.jwks)
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pascaldekloe/jwt"
+)
+
+// writeKeys writes an Ed25519 key "ed", an RSA key "rsa" and the public half
+// of an Ed25519 key "public" to a new directory
+func writeKeys(t *testing.T) (string, ed25519.PublicKey, *rsa.PublicKey) {
+	t.Helper()
+	dir := t.TempDir()
+
+	write := func(kid, typ string, der []byte) {
+		b := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+		err := os.WriteFile(filepath.Join(dir, kid+".pem"), b, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("ed", "PRIVATE KEY", der)
+
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("rsa", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivate))
+
+	otherPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err = x509.MarshalPKIXPublicKey(otherPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("public", "PUBLIC KEY", der)
+
+	return dir, edPublic, &rsaPrivate.PublicKey
+}
+
+func signTestToken(t *testing.T, keys *signingKeys) []byte {
+	t.Helper()
+
+	var claims jwt.Claims
+	claims.Subject = "1"
+	claims.Expires = jwt.NewNumericTime(time.Now().Add(time.Minute))
+
+	token, err := keys.sign(&claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestSigningKeys(t *testing.T) {
+	dir, _, _ := writeKeys(t)
+
+	for _, kid := range []string{"ed", "rsa"} {
+		t.Run(kid, func(t *testing.T) {
+			keys, err := loadSigningKeys(dir, kid, nil, "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token := signTestToken(t, keys)
+			claims, err := keys.check(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.KeyID != kid || claims.Subject != "1" {
+				t.Errorf("got kid %q subject %q", claims.KeyID, claims.Subject)
+			}
+
+			// tokens of the previous key pass until it is retired
+			other := "rsa"
+			if kid == "rsa" {
+				other = "ed"
+			}
+			next, err := loadSigningKeys(dir, other, nil, "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := next.check(token); err != nil {
+				t.Errorf("token of the previous key: %v", err)
+			}
+
+			retired, err := loadSigningKeys(dir, other, []string{" " + kid}, "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := retired.check(token); err == nil {
+				t.Error("token of a retired key was accepted")
+			}
+			if _, err := retired.check(signTestToken(t, retired)); err != nil {
+				t.Errorf("token of the active key after retiring %s: %v", kid, err)
+			}
+		})
+	}
+}
+
+func TestSigningKeysErrors(t *testing.T) {
+	dir, _, _ := writeKeys(t)
+
+	tests := []struct {
+		name    string
+		dir     string
+		active  string
+		retired []string
+		secret  string
+		accept  bool
+		want    string
+	}{
+		{name: "nothing configured", want: "either a jwt secret or a jwt key directory is required"},
+		{name: "public key only", dir: dir, active: "public", want: `active jwt key "public" has no private key`},
+		{name: "unknown kid", dir: dir, active: "missing", want: `active jwt key "missing" has no private key`},
+		{name: "retired active key", dir: dir, active: "ed", retired: []string{"ed"}, want: `active jwt key "ed" has no private key`},
+		{name: "hs256 without secret", dir: dir, active: "ed", accept: true, want: "accepting HS256 tokens requires a jwt secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadSigningKeys(tt.dir, tt.active, tt.retired, tt.secret, tt.accept)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+
+	err := os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("not a key"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = loadSigningKeys(dir, "ed", nil, "", false)
+	if err == nil || !strings.Contains(err.Error(), "jwt key broken") {
+		t.Errorf("got %v for a file without a key", err)
+	}
+}
+
+func TestSigningKeysSecret(t *testing.T) {
+	dir, _, _ := writeKeys(t)
+
+	hmacKeys, err := loadSigningKeys("", "", nil, "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := signTestToken(t, hmacKeys)
+	if _, err := hmacKeys.check(token); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := loadSigningKeys(dir, "ed", nil, "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.check(token); err == nil {
+		t.Error("HS256 token accepted next to a key directory without opting in")
+	}
+
+	keys, err = loadSigningKeys(dir, "ed", nil, "secret", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.check(token); err != nil {
+		t.Errorf("HS256 token rejected after opting in: %v", err)
+	}
+	if claims, _ := keys.check(signTestToken(t, keys)); claims == nil || claims.KeyID != "ed" {
+		t.Error("new tokens are not signed with the active key")
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	dir, edPublic, rsaPublic := writeKeys(t)
+
+	app := newTestApplication(t)
+	keys, err := loadSigningKeys(dir, "ed", []string{"public"}, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.keys = keys
+
+	rec := do(t, app.routes(), http.MethodGet, "/.well-known/jwks.json", nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/jwk-set+json" {
+		t.Fatalf("got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err = json.Unmarshal(rec.Body.Bytes(), &set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kids := make(map[string]jwk)
+	for _, k := range set.Keys {
+		kids[k.Kid] = k
+	}
+	if len(kids) != 2 {
+		t.Fatalf("got keys %v, want ed and rsa", kids)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(kids["ed"].X)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k := kids["ed"]; k.Kty != "OKP" || k.Crv != "Ed25519" || k.Alg != jwt.EdDSA || !edPublic.Equal(ed25519.PublicKey(x)) {
+		t.Errorf("ed key does not round-trip: %+v", k)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(kids["rsa"].N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(kids["rsa"].E)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	if k := kids["rsa"]; k.Kty != "RSA" || k.Alg != jwt.RS256 || !rsaPublic.Equal(got) {
+		t.Errorf("rsa key does not round-trip: %+v", k)
+	}
+}
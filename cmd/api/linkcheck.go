@@ -0,0 +1,132 @@
+package main
+
+import (
+	"backend/linkcheck"
+	"backend/models"
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// runLinkChecker checks all links right away and then every configured interval
+func (app *application) runLinkChecker(ctx context.Context) {
+	ticker := time.NewTicker(app.config.linkcheck.interval)
+	defer ticker.Stop()
+
+	for {
+		err := app.checkLinks(ctx)
+		if err != nil {
+			app.logger.Error("link check", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (app *application) checkLinks(ctx context.Context) error {
+	targets, err := app.models.DB.LinkTargets()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var all []string
+	for _, t := range targets {
+		if !seen[t.URL] {
+			seen[t.URL] = true
+			all = append(all, t.URL)
+		}
+	}
+
+	statuses, err := app.models.DB.LinkStatuses(all)
+	if err != nil {
+		return err
+	}
+
+	// skip links checked recently, e.g. by an instance started a moment ago
+	var urls []string
+	for _, u := range all {
+		if ls, ok := statuses[u]; ok && time.Since(ls.CheckedAt) < app.config.linkcheck.interval/2 {
+			continue
+		}
+		urls = append(urls, u)
+	}
+
+	checker := linkcheck.New(app.config.linkcheck.concurrency, app.config.linkcheck.hostInterval, 30*time.Second)
+
+	broken := 0
+	checker.CheckAll(ctx, urls, func(ls models.LinkStatus) {
+		if ls.Broken {
+			broken++
+		}
+		err := app.models.DB.SaveLinkStatus(ls)
+		if err != nil {
+			app.logger.Error("link check", "url", ls.URL, "error", err)
+		}
+	})
+
+	app.logger.Info("link check", "checked", len(urls), "broken", broken)
+
+	// listings asked with linkStatus=true show the new statuses
+	app.cache.purge()
+	return ctx.Err()
+}
+
+func (app *application) getBrokenLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := app.db(r).BrokenLinks()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, links, "links")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// withLinkStatus reports whether the request asked for link_status fields
+func withLinkStatus(r *http.Request) bool {
+	ls, _ := strconv.ParseBool(r.URL.Query().Get("linkStatus"))
+	return ls
+}
+
+func (app *application) setCourseLinkStatus(r *http.Request, courses ...*models.Course) error {
+	var urls []string
+	for _, c := range courses {
+		urls = append(urls, c.Daadlink)
+	}
+
+	statuses, err := app.db(r).LinkStatuses(urls)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range courses {
+		c.LinkStatus = statuses[c.Daadlink]
+	}
+	return nil
+}
+
+func (app *application) setArticleLinkStatus(r *http.Request, articles ...*models.Article) error {
+	var urls []string
+	for _, a := range articles {
+		urls = append(urls, a.Link)
+	}
+
+	statuses, err := app.db(r).LinkStatuses(urls)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range articles {
+		a.LinkStatus = statuses[a.Link]
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"backend/models"
+	"context"
+	"strconv"
+	"sync"
+)
+
+// batchLoader collects the ids requested while one level of a GraphQL query
+// is resolved and fetches them all at once when the first thunk is called.
+// Results are cached for the rest of the request.
+type batchLoader[V any] struct {
+	mu      sync.Mutex
+	fetch   func(ids []int) (map[int]V, error)
+	pending []int
+	loaded  map[int]V
+	done    map[int]bool
+	err     error
+}
+
+func newBatchLoader[V any](fetch func(ids []int) (map[int]V, error)) *batchLoader[V] {
+	return &batchLoader[V]{
+		fetch:  fetch,
+		loaded: make(map[int]V),
+		done:   make(map[int]bool),
+	}
+}
+
+// load queues id and returns a thunk the GraphQL executor calls once the
+// current level has been resolved
+func (l *batchLoader[V]) load(id int) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.done[id] {
+		l.done[id] = true
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			ids := l.pending
+			l.pending = nil
+
+			values, err := l.fetch(ids)
+			if err != nil {
+				l.err = err
+			}
+			for id, v := range values {
+				l.loaded[id] = v
+			}
+		}
+
+		if l.err != nil {
+			return nil, l.err
+		}
+		return l.loaded[id], nil
+	}
+}
+
+// prime caches a value loaded by another query
+func (l *batchLoader[V]) prime(id int, v V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.done[id] = true
+	l.loaded[id] = v
+}
+
+// graphQLLoaders are the loaders of one GraphQL request
+type graphQLLoaders struct {
+	db                *models.DBModel
+	filters           *catalogueFilters
+	courses           *batchLoader[*models.Course]
+	universities      *batchLoader[*models.University]
+	articles          *batchLoader[*models.Article]
+	courseLanguages   *batchLoader[[]models.Language]
+	courseArticles    *batchLoader[[]models.CourseArticle]
+	articleCourses    *batchLoader[[]models.ArticleCourse]
+	universityCourses *batchLoader[[]*models.Course]
+}
+
+const graphQLLoadersKey contextKey = "graphQLLoaders"
+
+func newGraphQLLoaders(db *models.DBModel, filters *catalogueFilters) *graphQLLoaders {
+	return &graphQLLoaders{
+		db:                db,
+		filters:           filters,
+		courses:           newBatchLoader(db.CoursesByIDs),
+		universities:      newBatchLoader(db.UniversitiesByIDs),
+		articles:          newBatchLoader(db.ArticlesByIDs),
+		courseLanguages:   newBatchLoader(db.LanguagesByCourseIDs),
+		courseArticles:    newBatchLoader(db.ArticlesByCourseIDs),
+		articleCourses:    newBatchLoader(db.CoursesByArticleIDs),
+		universityCourses: newBatchLoader(db.CoursesByUniversityIDs),
+	}
+}
+
+func loadersFrom(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey).(*graphQLLoaders)
+}
+
+// primeCourses caches listed courses so nested references to them are not fetched again
+func (l *graphQLLoaders) primeCourses(courses []*models.Course) {
+	for _, c := range courses {
+		l.courses.prime(c.ID, c)
+	}
+}
+
+func (l *graphQLLoaders) primeArticles(articles []*models.Article) {
+	for _, a := range articles {
+		l.articles.prime(a.ID, a)
+	}
+}
+
+// university loads the university of a course, whose id is stored as text
+func (l *graphQLLoaders) university(c *models.Course) func() (interface{}, error) {
+	id, err := strconv.Atoi(c.UniversityId)
+	if err != nil {
+		return func() (interface{}, error) { return nil, nil }
+	}
+	return l.universities.load(id)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const requestIDKey contextKey = "requestId"
+
+// requestIDFrom returns the id set by the requestID middleware
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// newLogger returns a JSON logger writing to w that adds the request id of
+// the context to every line and redacts secrets
+func newLogger(w io.Writer, level string) (*slog.Logger, error) {
+	var l slog.Level
+	err := l.UnmarshalText([]byte(level))
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       l,
+		ReplaceAttr: redact,
+	})
+	return slog.New(contextHandler{h}), nil
+}
+
+// contextHandler adds the request and trace ids of the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestIDFrom(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// redact hides the values of attributes whose name looks like a secret
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if sensitive(a.Key) {
+		return slog.String(a.Key, "[REDACTED]")
+	}
+	return a
+}
+
+func sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"authorization", "password", "secret", "token", "cookie", "api-key", "api_key", "dsn"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestID takes the request id from the X-Request-ID header, or generates
+// one, and puts it on the context and the response
+func (app *application) requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set("X-Request-ID", id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey, id)))
+	})
+}
+
+// validRequestID accepts ids of up to 128 letters, digits, dashes,
+// underscores and dots, so clients cannot inject anything into the logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logWriter records what the handler wrote for the request log line
+type logWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+	err    error
+}
+
+func (lw *logWriter) WriteHeader(status int) {
+	if lw.status == 0 {
+		lw.status = status
+	}
+	lw.ResponseWriter.WriteHeader(status)
+}
+
+func (lw *logWriter) Write(b []byte) (int, error) {
+	if lw.status == 0 {
+		lw.status = http.StatusOK
+	}
+	n, err := lw.ResponseWriter.Write(b)
+	lw.bytes += n
+	return n, err
+}
+
+func (lw *logWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
+
+// recordError keeps err for the request log line when w is, or wraps, a logWriter
+func recordError(w http.ResponseWriter, err error) {
+	for {
+		switch rw := w.(type) {
+		case *logWriter:
+			rw.err = err
+			return
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// logRequests writes one line per request, with the error sent by errorJSON
+// if any. The request headers are only logged at debug level.
+func (app *application) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		lw := &logWriter{ResponseWriter: w}
+
+		next.ServeHTTP(lw, r)
+
+		if lw.status == 0 {
+			lw.status = http.StatusOK
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case lw.status >= 500:
+			level = slog.LevelError
+		case lw.status >= 400:
+			level = slog.LevelWarn
+		case r.URL.Path == "/livez" || r.URL.Path == "/readyz":
+			// probes run every few seconds
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", lw.status),
+			slog.Int("bytes", lw.bytes),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", app.clientIP(r)),
+		}
+		if lw.err != nil {
+			attrs = append(attrs, slog.String("error", lw.err.Error()))
+		}
+		if app.logger.Enabled(r.Context(), slog.LevelDebug) {
+			var headers []any
+			for name, values := range r.Header {
+				headers = append(headers, slog.String(name, strings.Join(values, ", ")))
+			}
+			attrs = append(attrs, slog.Group("headers", headers...))
+		}
+
+		app.logger.LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
+
+// recoverPanic answers 500 instead of dropping the connection when a handler panics
+func (app *application) recoverPanic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			app.logger.ErrorContext(r.Context(), "panic", "error", fmt.Sprint(rec), "stack", string(debug.Stack()))
+			w.Header().Set("Connection", "close")
+			app.errorJSON(w, errors.New("internal server error"), http.StatusInternalServerError)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,31 +1,81 @@
 package main
 
 import (
+	"backend/mailer"
 	"backend/models"
 	"context"
 	"database/sql"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/graphql-go/graphql"
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
-	"github.com/urfave/negroni"
 )
 
 const version = "1.0.0"
 
 type config struct {
-	port int
-	env  string
-	db   struct {
-		dsn string
+	port            int
+	grpcPort        int
+	env             string
+	trustProxy      bool
+	apiKeysRequired bool
+	logLevel        string
+	cacheEntries    int
+	readyTimeout    time.Duration
+	db              struct {
+		dsn          string
+		maxOpenConns int
 	}
 	jwt struct {
-		secret string
+		secret     string
+		keys       string
+		activeKid  string
+		retired    string
+		acceptHS   bool
+		accessTTL  time.Duration
+		refreshTTL time.Duration
+	}
+	daad struct {
+		snapshots string
+	}
+	grpcTLS struct {
+		cert string
+		key  string
+	}
+	metrics struct {
+		addr  string
+		token string
+	}
+	shutdown struct {
+		timeout    time.Duration
+		drainDelay time.Duration
+	}
+	trace struct {
+		exporter    string
+		sampleRatio float64
+	}
+	linkcheck struct {
+		interval     time.Duration
+		concurrency  int
+		hostInterval time.Duration
+	}
+	mail struct {
+		smtpAddr     string
+		smtpUsername string
+		smtpPassword string
+		from         string
+		appURL       string
 	}
 }
 
@@ -36,9 +86,20 @@ type AppStatus struct {
 }
 
 type application struct {
-	config config
-	logger *log.Logger
-	models models.Models
+	config            config
+	logger            *slog.Logger
+	models            models.Models
+	mailer            mailer.Mailer
+	keys              *signingKeys
+	signInGuard       *signInGuard
+	apiKeyBuckets     *tokenBuckets
+	submissionBuckets *tokenBuckets
+	graphQLSchema     graphql.Schema
+	cache             *responseCache
+	filters           *catalogueFilters
+	metrics           *metrics
+	health            health
+	mails             sync.WaitGroup
 }
 
 func main() {
@@ -63,47 +124,151 @@ func main() {
 		flag.StringVar(&cfg.jwt.secret, "jwt-secret", os.Getenv("JWT_SECRET"), "secrt")
 		addr = fmt.Sprintf("127.0.0.1:%d", cfg.port)
 	}
+	flag.StringVar(&cfg.jwt.keys, "jwt-keys", os.Getenv("JWT_KEYS_DIR"), "Directory of <kid>.pem Ed25519 or RSA signing keys, tokens are signed with the jwt secret when empty")
+	flag.StringVar(&cfg.jwt.activeKid, "jwt-active-kid", os.Getenv("JWT_ACTIVE_KID"), "Key id of the key new tokens are signed with")
+	flag.StringVar(&cfg.jwt.retired, "jwt-retired-kids", os.Getenv("JWT_RETIRED_KIDS"), "Comma separated key ids whose tokens are no longer accepted")
+	flag.BoolVar(&cfg.jwt.acceptHS, "jwt-accept-hs256", os.Getenv("JWT_ACCEPT_HS256") == "true", "Also accept HS256 tokens signed with the jwt secret next to jwt-keys, only while switching to asymmetric keys")
+	flag.DurationVar(&cfg.jwt.accessTTL, "jwt-access-ttl", 15*time.Minute, "Lifetime of access tokens")
+	flag.DurationVar(&cfg.jwt.refreshTTL, "jwt-refresh-ttl", 30*24*time.Hour, "Lifetime of refresh tokens")
+	flag.IntVar(&cfg.grpcPort, "grpc-port", 4001, "Port of the gRPC catalogue service, 0 disables it")
+	flag.StringVar(&cfg.grpcTLS.cert, "grpc-tls-cert", os.Getenv("GRPC_TLS_CERT"), "TLS certificate of the gRPC service, which only listens on localhost without one")
+	flag.StringVar(&cfg.grpcTLS.key, "grpc-tls-key", os.Getenv("GRPC_TLS_KEY"), "TLS private key of the gRPC service")
+	flag.BoolVar(&cfg.trustProxy, "trust-proxy", os.Getenv("ENV") == "PROD", "Take client addresses from the X-Forwarded-For header set by the proxy in front of the API")
+	flag.BoolVar(&cfg.apiKeysRequired, "api-keys-required", os.Getenv("API_KEYS_REQUIRED") == "true", "Reject public requests without an X-API-Key header")
+	flag.StringVar(&cfg.logLevel, "log-level", envOr("LOG_LEVEL", "info"), "Minimum level of logged lines (debug|info|warn|error)")
+	flag.StringVar(&cfg.metrics.addr, "metrics-addr", os.Getenv("METRICS_ADDR"), "Separate host:port to serve /metrics on instead of the API port")
+	flag.StringVar(&cfg.metrics.token, "metrics-token", os.Getenv("METRICS_TOKEN"), "Bearer token required to read /metrics, open when empty")
+	flag.StringVar(&cfg.trace.exporter, "trace-exporter", envOr("TRACE_EXPORTER", "none"), "Where spans are sent (otlp|stdout|none), otlp is configured by the OTEL_EXPORTER_OTLP_* variables")
+	flag.Float64Var(&cfg.trace.sampleRatio, "trace-sample-ratio", 1, "Share of new traces that are recorded")
+	flag.IntVar(&cfg.db.maxOpenConns, "db-max-open-conns", 25, "Maximum number of open database connections, 0 for no limit")
+	flag.DurationVar(&cfg.readyTimeout, "ready-timeout", 2*time.Second, "Time the database checks of /readyz may take")
+	flag.DurationVar(&cfg.shutdown.timeout, "shutdown-timeout", 30*time.Second, "Time in-flight requests and background workers get to finish on SIGINT or SIGTERM")
+	flag.DurationVar(&cfg.shutdown.drainDelay, "drain-delay", 0, "Time /readyz fails before the server stops accepting connections on shutdown")
+	flag.IntVar(&cfg.cacheEntries, "cache-entries", 1000, "Maximum number of public responses kept in memory, 0 disables the response cache")
+	flag.StringVar(&cfg.daad.snapshots, "daad-snapshots", envOr("DAAD_SNAPSHOT_DIR", "daad-snapshots"), "Directory holding one sub directory per DAAD snapshot")
+	flag.DurationVar(&cfg.linkcheck.interval, "linkcheck-interval", 24*time.Hour, "How often all external links are checked, 0 disables the link checker")
+	flag.IntVar(&cfg.linkcheck.concurrency, "linkcheck-concurrency", 4, "Number of links checked at once")
+	flag.DurationVar(&cfg.linkcheck.hostInterval, "linkcheck-host-interval", 2*time.Second, "Minimum delay between two requests to the same host")
+	flag.StringVar(&cfg.mail.smtpAddr, "smtp-addr", os.Getenv("SMTP_ADDR"), "SMTP server host:port, required in production, account mails are only logged when empty")
+	flag.StringVar(&cfg.mail.smtpUsername, "smtp-username", os.Getenv("SMTP_USERNAME"), "SMTP username")
+	flag.StringVar(&cfg.mail.smtpPassword, "smtp-password", os.Getenv("SMTP_PASSWORD"), "SMTP password")
+	flag.StringVar(&cfg.mail.from, "mail-from", envOr("MAIL_FROM", "Go Germany <no-reply@localhost>"), "Sender of account mails")
+	flag.StringVar(&cfg.mail.appURL, "app-url", envOr("APP_URL", "http://localhost:3000"), "Frontend base URL used in account mail links")
 	flag.Parse()
 
-	logger := log.New(os.Stdout, "", log.Ldate|log.Ltime)
+	logger, err := newLogger(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	db, err := openDB(cfg)
+	if cfg.env == "production" && cfg.mail.smtpAddr == "" {
+		logger.Error("smtp-addr is required in production")
+		os.Exit(1)
+	}
+
+	var retired []string
+	if cfg.jwt.retired != "" {
+		retired = strings.Split(cfg.jwt.retired, ",")
+	}
+
+	keys, err := loadSigningKeys(cfg.jwt.keys, cfg.jwt.activeKid, retired, cfg.jwt.secret, cfg.jwt.acceptHS)
 	if err != nil {
-		logger.Fatal(err)
+		logger.Error("loading signing keys", "error", err)
+		os.Exit(1)
+	}
+
+	schema, err := newGraphQLSchema()
+	if err != nil {
+		logger.Error("building graphql schema", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := setupTracing(cfg.trace.exporter, cfg.trace.sampleRatio)
+	if err != nil {
+		logger.Error("setting up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
+	metrics := newMetrics()
+
+	db, err := openDB(cfg, metrics.observeQuery, traceQuery)
+	if err != nil {
+		logger.Error("opening database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
 	app := &application{
-		config: cfg,
-		logger: logger,
-		models: models.NewModels(db),
+		config:            cfg,
+		logger:            logger,
+		models:            models.NewModels(db),
+		mailer:            &mailer.Log{Logger: logger},
+		keys:              keys,
+		signInGuard:       newSignInGuard(),
+		apiKeyBuckets:     newTokenBuckets(),
+		submissionBuckets: newTokenBuckets(),
+		graphQLSchema:     schema,
+		cache:             newResponseCache(cfg.cacheEntries),
+		filters:           &catalogueFilters{},
+		metrics:           metrics,
 	}
+	metrics.watch(db, app.cache)
 
-	n := negroni.Classic() // Includes some default middlewares
-	n.UseHandler(app.routes())
+	if cfg.mail.smtpAddr != "" {
+		app.mailer = &mailer.SMTP{
+			Addr:     cfg.mail.smtpAddr,
+			Username: cfg.mail.smtpUsername,
+			Password: cfg.mail.smtpPassword,
+			From:     cfg.mail.from,
+		}
+	}
 
 	srv := &http.Server{
 		// Addr:         fmt.Sprintf("127.0.0.1:%d", cfg.port),
-		Addr: addr,
-		// Handler:      app.routes(),
-		Handler:      n,
+		Addr:         addr,
+		Handler:      app.traceRequests(app.requestID(app.logRequests(app.instrument(app.recoverPanic(app.routes()))))),
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
 
-	logger.Println("Starting server on port", cfg.port)
-	err = srv.ListenAndServe()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	lis, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		logger.Error("server", "error", err)
+		os.Exit(1)
+	}
+
+	err = app.serve(ctx, srv, lis, app.backgroundWorkers())
 	if err != nil {
-		log.Println(err)
+		logger.Error("server", "error", err)
 	}
 }
 
-func openDB(cfg config) (*sql.DB, error) {
-	db, err := sql.Open("postgres", cfg.db.dsn)
+// envOr returns the environment variable key, or fallback when it is unset
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func openDB(cfg config, hooks ...models.QueryHook) (*sql.DB, error) {
+	connector, err := models.NewConnector(cfg.db.dsn, hooks...)
 	if err != nil {
 		return nil, err
 	}
+	db := sql.OpenDB(connector)
+	db.SetMaxOpenConns(cfg.db.maxOpenConns)
+	// without a limit the idle connections keep the default of database/sql,
+	// as 0 idle connections would mean opening one for every query
+	if cfg.db.maxOpenConns > 0 {
+		db.SetMaxIdleConns(cfg.db.maxOpenConns)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"backend/models"
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// metrics are the Prometheus collectors of the API, served on /metrics
+type metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	queryDuration   *prometheus.HistogramVec
+	queryErrors     *prometheus.CounterVec
+	cacheRequests   *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by route pattern and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database statement latency by models method, including reading the rows.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 10},
+		}, []string{"method"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Failed database statements by models method.",
+		}, []string{"method"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Response cache lookups by route pattern and result (hit or miss).",
+		}, []string{"route", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.queryDuration,
+		m.queryErrors,
+		m.cacheRequests,
+	)
+
+	return m
+}
+
+// watch adds the pool statistics of db and the size of the response cache
+func (m *metrics) watch(db *sql.DB, cache *responseCache) {
+	m.registry.MustRegister(
+		collectors.NewDBStatsCollector(db, "postgres"),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "cache_entries",
+			Help: "Responses currently kept by the response cache.",
+		}, func() float64 {
+			cache.mu.RLock()
+			defer cache.mu.RUnlock()
+			return float64(len(cache.entries))
+		}),
+	)
+}
+
+// observeQuery is the models.QueryHook recording query durations and errors
+func (m *metrics) observeQuery(ctx context.Context, q models.Query) {
+	m.queryDuration.WithLabelValues(q.Method).Observe(q.Duration.Seconds())
+	if q.Err != nil && !errors.Is(q.Err, context.Canceled) {
+		m.queryErrors.WithLabelValues(q.Method).Inc()
+	}
+}
+
+func (m *metrics) cacheLookup(r *http.Request, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheRequests.WithLabelValues(routeFrom(r), result).Inc()
+}
+
+const routeKey contextKey = "route"
+
+// setRoute records the pattern of the route that matched r for instrument
+// and names the span of the request after it
+func setRoute(r *http.Request, pattern string) {
+	if route, ok := r.Context().Value(routeKey).(*string); ok {
+		*route = pattern
+	}
+
+	span := trace.SpanFromContext(r.Context())
+	span.SetName(r.Method + " " + pattern)
+	span.SetAttributes(semconv.HTTPRoute(pattern))
+}
+
+func routeFrom(r *http.Request) string {
+	if route, ok := r.Context().Value(routeKey).(*string); ok && *route != "" {
+		return *route
+	}
+	return "unmatched"
+}
+
+// instrument counts requests and their latency by the route pattern set by
+// the router, so ids in paths do not create new series
+func (app *application) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(context.WithValue(r.Context(), routeKey, new(string)))
+
+		next.ServeHTTP(sw, r)
+
+		route := routeFrom(r)
+		method := methodLabel(r.Method)
+		status := strconv.Itoa(sw.status)
+		app.metrics.requests.WithLabelValues(method, route, status).Inc()
+		app.metrics.requestDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// methodLabel returns method if it is a standard method and "other" if not,
+// as clients can send any token as method
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "other"
+}
+
+// metricsHandler serves the metrics, requiring the metrics token as bearer
+// token when one is configured
+func (app *application) metricsHandler() http.Handler {
+	h := promhttp.HandlerFor(app.metrics.registry, promhttp.HandlerOpts{})
+	token := app.config.metrics.token
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			got := []byte(r.Header.Get("Authorization"))
+			if subtle.ConstantTimeCompare(got, []byte("Bearer "+token)) != 1 {
+				app.errorJSON(w, errors.New("unauthorized"), http.StatusUnauthorized)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// serveMetrics serves /metrics on the separate metrics address until ctx is done
+func (app *application) serveMetrics(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", app.metricsHandler())
+
+	srv := &http.Server{
+		Addr:         app.config.metrics.addr,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	app.logger.Info("starting metrics server", "addr", app.config.metrics.addr)
+	err := srv.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		app.logger.Error("metrics", "error", err)
+	}
+}
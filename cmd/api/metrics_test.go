@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstrumentMethodLabel(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.instrument(app.routes())
+
+	for _, method := range []string{http.MethodGet, "FOO", "BAR", "get"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/status", nil))
+	}
+
+	families, err := app.metrics.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]float64)
+	for _, f := range families {
+		if f.GetName() != "http_requests_total" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "method" {
+					got[l.GetValue()] += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	want := map[string]float64{http.MethodGet: 1, "other": 3}
+	if len(got) != len(want) || got[http.MethodGet] != 1 || got["other"] != 3 {
+		t.Errorf("got requests by method %v, want %v", got, want)
+	}
+}
@@ -1,8 +1,8 @@
 package main
 
 import (
+	"context"
 	"errors"
-	"log"
 	"net/http"
 	"os"
 	"strconv"
@@ -12,6 +12,25 @@ import (
 	"github.com/pascaldekloe/jwt"
 )
 
+type contextKey string
+
+const (
+	userIDKey    contextKey = "userId"
+	userRolesKey contextKey = "userRoles"
+)
+
+// userID returns the id of the user authenticated by checkToken
+func userID(r *http.Request) int {
+	id, _ := r.Context().Value(userIDKey).(int)
+	return id
+}
+
+// userRoles returns the roles carried by the token checked by checkToken
+func userRoles(r *http.Request) []string {
+	roles, _ := r.Context().Value(userRolesKey).([]string)
+	return roles
+}
+
 func (app *application) enableCORS(next http.Handler) http.Handler {
 	domain := ""
 	if os.Getenv("ENV") == "PROD" {
@@ -23,7 +42,7 @@ func (app *application) enableCORS(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", domain)
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, access-control-allow-origin, access-control-allow-headers, authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, access-control-allow-origin, access-control-allow-headers, authorization, x-api-key, x-request-id, traceparent, tracestate")
 
 		next.ServeHTTP(w, r)
 	})
@@ -33,43 +52,19 @@ func (app *application) checkToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Vary", "Authorization")
 
-		authHeader := r.Header.Get("Authorization")
-
-		if authHeader == "" {
-			// could set an anonymous user
-		}
-
-		headerParts := strings.Split(authHeader, " ")
-		if len(headerParts) != 2 {
-			app.errorJSON(w, errors.New("invalid auth header"))
-			return
-		}
-
-		if headerParts[0] != "Bearer" {
-			app.errorJSON(w, errors.New("unauthorized - no bearer"))
-			return
-		}
-
-		token := headerParts[1]
-
-		claims, err := jwt.HMACCheck([]byte(token), []byte(app.config.jwt.secret))
+		claims, status, err := app.verifyToken(r)
 		if err != nil {
-			app.errorJSON(w, errors.New("unauthorized - failed hmac check"), http.StatusForbidden)
+			app.errorJSON(w, err, status)
 			return
 		}
 
-		if !claims.Valid(time.Now()) {
-			app.errorJSON(w, errors.New("unauthorized - token expired"), http.StatusForbidden)
-			return
-		}
-
-		if !claims.AcceptAudience("https://noworneverev.github.io/go-germany/") {
-			app.errorJSON(w, errors.New("unauthorized - invalid audience"), http.StatusForbidden)
+		revoked, err := app.db(r).IsJTIRevoked(claims.ID)
+		if err != nil {
+			app.errorJSON(w, err, http.StatusInternalServerError)
 			return
 		}
-
-		if claims.Issuer != "https://noworneverev.github.io/go-germany/" {
-			app.errorJSON(w, errors.New("unauthorized - invalid issuer"), http.StatusForbidden)
+		if revoked {
+			app.errorJSON(w, errors.New("unauthorized - token revoked"), http.StatusForbidden)
 			return
 		}
 
@@ -79,8 +74,58 @@ func (app *application) checkToken(next http.Handler) http.Handler {
 			return
 		}
 
-		log.Println("Valid user:", userId)
+		var roles []string
+		claimed, _ := claims.Set["roles"].([]interface{})
+		for _, role := range claimed {
+			if role, ok := role.(string); ok {
+				roles = append(roles, role)
+			}
+		}
 
-		next.ServeHTTP(w, r)
+		app.logger.DebugContext(r.Context(), "valid user", "user_id", userId)
+
+		ctx := context.WithValue(r.Context(), userIDKey, int(userId))
+		ctx = context.WithValue(ctx, userRolesKey, roles)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// verifyToken checks the bearer access token of the request and returns its
+// claims, or the status code and error to respond with
+func (app *application) verifyToken(r *http.Request) (*jwt.Claims, int, error) {
+	authHeader := r.Header.Get("Authorization")
+
+	headerParts := strings.Split(authHeader, " ")
+	if len(headerParts) != 2 {
+		return nil, http.StatusBadRequest, errors.New("invalid auth header")
+	}
+
+	if headerParts[0] != "Bearer" {
+		return nil, http.StatusBadRequest, errors.New("unauthorized - no bearer")
+	}
+
+	token := headerParts[1]
+
+	claims, err := app.keys.check([]byte(token))
+	if err != nil {
+		return nil, http.StatusForbidden, errors.New("unauthorized - invalid signature")
+	}
+
+	if !claims.Valid(time.Now()) {
+		return nil, http.StatusForbidden, errors.New("unauthorized - token expired")
+	}
+
+	if !claims.AcceptAudience("https://noworneverev.github.io/go-germany/") {
+		return nil, http.StatusForbidden, errors.New("unauthorized - invalid audience")
+	}
+
+	if claims.Issuer != "https://noworneverev.github.io/go-germany/" {
+		return nil, http.StatusForbidden, errors.New("unauthorized - invalid issuer")
+	}
+
+	if claims.ID == "" {
+		return nil, http.StatusForbidden, errors.New("unauthorized - token has no id")
+	}
+
+	return claims, http.StatusOK, nil
+}
@@ -0,0 +1,578 @@
+package main
+
+import (
+	"backend/extract"
+	"backend/importer"
+	"backend/models"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// apiRoute documents one route registered in routes.go. The OpenAPI document
+// is built from these entries and requests are validated against them.
+type apiRoute struct {
+	Method     string
+	Path       string
+	Summary    string
+	Tag        string
+	Auth       bool
+	Permission string
+	APIKey     bool
+	Query      []apiParam
+	Form       []apiParam
+	// Body is a value of the json request body type
+	Body         interface{}
+	BodyRequired []string
+	Status       int
+	// Wrap is the envelope key writeJSON puts Response under
+	Wrap      string
+	Response  interface{}
+	Paginated bool
+	// Raw lists the content types of responses not written by writeJSON
+	Raw []string
+}
+
+// apiParam documents a query parameter or form field
+type apiParam struct {
+	Name        string
+	Type        string
+	Required    bool
+	Enum        []string
+	Description string
+}
+
+// pathParams documents the path parameters used by the routes
+var pathParams = map[string]apiParam{
+	"id":   {Name: "id", Type: "integer", Required: true},
+	"kind": {Name: "kind", Type: "string", Required: true, Enum: []string{importer.Universities, importer.Courses}},
+}
+
+var (
+	pagingQuery = []apiParam{
+		{Name: "pageNumber", Type: "integer", Required: true, Description: "1 based page number"},
+		{Name: "pageSize", Type: "integer", Required: true},
+	}
+	linkStatusQuery = apiParam{Name: "linkStatus", Type: "boolean", Description: "Include the last link check result"}
+	formatQuery     = apiParam{Name: "format", Type: "string", Enum: []string{"csv", "xlsx"}}
+
+	courseQuery = []apiParam{
+		{Name: "searchTerm", Type: "string"},
+		{Name: "courseTypes", Type: "string", Description: "Comma separated course types"},
+		{Name: "languages", Type: "string", Description: "Comma separated languages"},
+		{Name: "subjects", Type: "string", Description: "Comma separated subjects"},
+		{Name: "institutions", Type: "string", Description: "Comma separated university names"},
+		{Name: "isTu9", Type: "boolean"},
+		{Name: "isU15", Type: "boolean"},
+		{Name: "hasArticles", Type: "boolean"},
+		{Name: "orderBy", Type: "string"},
+		{Name: "hideLanguageArticle", Type: "boolean"},
+	}
+
+	articleQuery = []apiParam{
+		{Name: "searchTerm", Type: "string"},
+		{Name: "sources", Type: "string", Description: "Comma separated sources"},
+		{Name: "bsSchools", Type: "string"},
+		{Name: "bsDepartments", Type: "string"},
+		{Name: "msSchools", Type: "string"},
+		{Name: "msDepartments", Type: "string"},
+		{Name: "courseType", Type: "string"},
+		{Name: "hideApplication", Type: "boolean"},
+	}
+
+	statusQuery = []apiParam{
+		{Name: "status", Type: "string", Enum: []string{"pending", "approved", "rejected"}},
+	}
+
+	contentForm = []apiParam{
+		{Name: "id", Type: "integer"},
+		{Name: "link", Type: "string"},
+		{Name: "title", Type: "string"},
+		{Name: "author", Type: "string"},
+		{Name: "publishedAt", Type: "string", Description: "yyyy-mm-dd"},
+		{Name: "source", Type: "string"},
+		{Name: "authorBsSchool", Type: "string"},
+		{Name: "authorBsSchoolShort", Type: "string"},
+		{Name: "authorBsDepartment", Type: "string"},
+		{Name: "authorBsGpa", Type: "string"},
+		{Name: "authorMsSchool", Type: "string"},
+		{Name: "authorMsSchoolShort", Type: "string"},
+		{Name: "authorMsDepartment", Type: "string"},
+		{Name: "authorMsGpa", Type: "string"},
+		{Name: "authorToefl", Type: "string"},
+		{Name: "authorIelts", Type: "string"},
+		{Name: "authorGre", Type: "string"},
+		{Name: "authorGmat", Type: "string"},
+		{Name: "authorTestdaf", Type: "string"},
+		{Name: "authorGoethe", Type: "string"},
+		{Name: "courseType", Type: "string"},
+		{Name: "content", Type: "string"},
+	}
+
+	submissionForm = append(append([]apiParam{}, contentForm...),
+		apiParam{Name: "courseId", Type: "integer", Description: "Repeated, one per result"},
+		apiParam{Name: "result", Type: "string", Enum: []string{"Admission", "Rejection"}, Description: "Repeated, one per courseId"},
+		apiParam{Name: "isDecision", Type: "boolean", Description: "Repeated, one per courseId"},
+	)
+
+	reviewForm = []apiParam{
+		{Name: "reviewerNote", Type: "string"},
+	}
+)
+
+// apiKeyRequest documents the body of createAPIKey, which decodes into models.APIKey
+type apiKeyRequest struct {
+	Name          string `json:"name"`
+	OwnerEmail    string `json:"owner_email"`
+	RatePerMinute int    `json:"rate_per_minute"`
+	Burst         int    `json:"burst"`
+	DailyQuota    int    `json:"daily_quota"`
+}
+
+var apiRoutes = []apiRoute{
+	{Method: http.MethodGet, Path: "/status", Tag: "status", Summary: "Service status", Response: AppStatus{}, Raw: []string{"application/json"}},
+	{Method: http.MethodGet, Path: "/livez", Tag: "status", Summary: "Whether the process is up", Wrap: "liveness", Response: healthReport{}},
+	{Method: http.MethodGet, Path: "/readyz", Tag: "status", Summary: "Whether the instance can take traffic, 503 with the failed checks otherwise", Wrap: "readiness", Response: healthReport{}},
+	{Method: http.MethodGet, Path: "/.well-known/jwks.json", Tag: "status", Summary: "Public keys access tokens are signed with", Raw: []string{"application/jwk-set+json"}},
+	{Method: http.MethodGet, Path: "/v1/openapi.json", Tag: "status", Summary: "This document", Raw: []string{"application/json"}},
+	{Method: http.MethodGet, Path: "/metrics", Tag: "status", Summary: "Prometheus metrics, behind the metrics token when one is set. Not found when served on a separate address.", Raw: []string{"text/plain"}},
+
+	{Method: http.MethodPost, Path: "/v1/account/signin", Tag: "account", Summary: "Sign in, returns an access token and sets the refresh token cookie",
+		Body: Credentials{}, BodyRequired: []string{"username", "password"}, Wrap: "response", Response: ""},
+	{Method: http.MethodPost, Path: "/v1/account/refresh", Tag: "account", Summary: "Rotate the refresh token from the cookie or body and return a new access token",
+		Body: refreshRequest{}, Wrap: "response", Response: ""},
+	{Method: http.MethodPost, Path: "/v1/account/logout", Tag: "account", Summary: "Revoke the refresh token family and the bearer token",
+		Body: refreshRequest{}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/account/register", Tag: "account", Summary: "Create an account and mail a verification link, the response is the same when the email is taken",
+		Body: accountRequest{}, BodyRequired: []string{"email", "password"}, Status: http.StatusAccepted, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/account/verify", Tag: "account", Summary: "Verify an email address",
+		Body: accountRequest{}, BodyRequired: []string{"token"}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/account/verify/resend", Tag: "account", Summary: "Mail a new verification link",
+		Body: accountRequest{}, BodyRequired: []string{"email"}, Status: http.StatusAccepted, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/account/password/forgot", Tag: "account", Summary: "Mail a password reset link",
+		Body: accountRequest{}, BodyRequired: []string{"email"}, Status: http.StatusAccepted, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/account/password/reset", Tag: "account", Summary: "Set a new password with a reset token",
+		Body: accountRequest{}, BodyRequired: []string{"token", "password"}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodGet, Path: "/v1/account", Tag: "account", Summary: "The signed in user with their permissions",
+		Auth: true, Wrap: "account", Response: accountResp{}},
+
+	{Method: http.MethodGet, Path: "/v1/course/:id", Tag: "courses", Summary: "One course with its languages and articles",
+		APIKey: true, Query: []apiParam{linkStatusQuery}, Wrap: "course", Response: models.Course{}},
+	{Method: http.MethodGet, Path: "/v1/courses", Tag: "courses", Summary: "Filtered page of courses",
+		APIKey: true, Query: concatParams(pagingQuery, courseQuery, []apiParam{linkStatusQuery}), Wrap: "courses", Response: []*models.Course{}, Paginated: true},
+	{Method: http.MethodGet, Path: "/v1/courses/filters", Tag: "courses", Summary: "Values available to the course filters",
+		APIKey: true, Wrap: "filters", Response: models.Filters{}},
+	{Method: http.MethodGet, Path: "/v1/courses/compare", Tag: "courses", Summary: "Side by side comparison of 2 to 5 courses",
+		APIKey: true, Query: []apiParam{{Name: "ids", Type: "string", Required: true, Description: "Comma separated course ids"}}, Wrap: "comparison", Response: models.CourseComparison{}},
+	{Method: http.MethodGet, Path: "/v1/courses/export", Tag: "courses", Summary: "Every course matching the filters as a spreadsheet",
+		APIKey: true, Query: concatParams(courseQuery, []apiParam{formatQuery}), Raw: []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}},
+
+	{Method: http.MethodGet, Path: "/v1/article/:id", Tag: "articles", Summary: "One article with the courses it reports on",
+		APIKey: true, Query: []apiParam{linkStatusQuery}, Wrap: "article", Response: models.Article{}},
+	{Method: http.MethodGet, Path: "/v1/articles", Tag: "articles", Summary: "Filtered page of articles",
+		APIKey: true, Query: concatParams(pagingQuery, articleQuery, []apiParam{linkStatusQuery}), Wrap: "articles", Response: []*models.Article{}, Paginated: true},
+	{Method: http.MethodGet, Path: "/v1/articles/filters", Tag: "articles", Summary: "Values available to the article filters",
+		APIKey: true, Wrap: "articleFilters", Response: models.ArticleFilters{}},
+	{Method: http.MethodGet, Path: "/v1/articles/export", Tag: "articles", Summary: "Every article matching the filters as a spreadsheet",
+		APIKey: true, Query: concatParams(articleQuery, []apiParam{formatQuery}), Raw: []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}},
+
+	{Method: http.MethodGet, Path: "/v1/graphql", Tag: "graphql", Summary: "Run a GraphQL query over courses, universities and articles",
+		APIKey: true, Query: []apiParam{
+			{Name: "query", Type: "string", Required: true},
+			{Name: "variables", Type: "string", Description: "JSON object"},
+			{Name: "operationName", Type: "string"},
+		}, Response: graphql.Result{}, Raw: []string{"application/json"}},
+	{Method: http.MethodPost, Path: "/v1/graphql", Tag: "graphql", Summary: "Run a GraphQL query over courses, universities and articles",
+		APIKey: true, Body: graphQLRequest{}, BodyRequired: []string{"query"}, Response: graphql.Result{}, Raw: []string{"application/json"}},
+
+	{Method: http.MethodPost, Path: "/v1/submissions", Tag: "submissions", Summary: "Submit an article and course results for moderation, limited per client address",
+		Form: concatParams(submissionForm, []apiParam{{Name: "email", Type: "string"}}), Status: http.StatusAccepted, Wrap: "response", Response: jsonResp{}},
+
+	{Method: http.MethodPost, Path: "/v1/admin/editcourse", Tag: "admin", Summary: "Insert or update a course",
+		Auth: true, Permission: permEditCourses, Form: []apiParam{
+			{Name: "id", Type: "integer"}, {Name: "universityId", Type: "integer"}, {Name: "courseTypes", Type: "string"},
+			{Name: "nameEn", Type: "string"}, {Name: "nameEnShort", Type: "string"}, {Name: "nameCh", Type: "string"},
+			{Name: "nameChShort", Type: "string"}, {Name: "tuitionFees", Type: "string"}, {Name: "beginning", Type: "string"},
+			{Name: "subjects", Type: "string"}, {Name: "daadlink", Type: "string"}, {Name: "isElearning", Type: "boolean"},
+			{Name: "isCompleteOnlinePossible", Type: "boolean"}, {Name: "isFromDaad", Type: "boolean"},
+			{Name: "programmeDuration", Type: "string"}, {Name: "applicationDeadline", Type: "string"},
+		}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/edituniversity", Tag: "admin", Summary: "Insert or update a university",
+		Auth: true, Permission: permEditUniversities, Form: []apiParam{
+			{Name: "id", Type: "integer"}, {Name: "nameEn", Type: "string"}, {Name: "nameCh", Type: "string"},
+			{Name: "city", Type: "string"}, {Name: "link", Type: "string"}, {Name: "qsRanking", Type: "integer"},
+			{Name: "isFromDaad", Type: "boolean"}, {Name: "isTu9", Type: "boolean"}, {Name: "isU15", Type: "boolean"},
+		}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/editcontent", Tag: "admin", Summary: "Insert an article text",
+		Auth: true, Permission: permEditArticles, Form: contentForm, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/editarticle", Tag: "admin", Summary: "Link an article to a course result",
+		Auth: true, Permission: permEditArticles, Form: []apiParam{
+			{Name: "id", Type: "integer"}, {Name: "courseId", Type: "integer"},
+			{Name: "result", Type: "string", Enum: []string{"Admission", "Rejection"}}, {Name: "isDecision", Type: "boolean"},
+		}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/suggest/content", Tag: "admin", Summary: "Suggest applicant background fields from article text",
+		Auth: true, Permission: permEditArticles, Form: []apiParam{{Name: "content", Type: "string"}, {Name: "draftId", Type: "integer"}},
+		Wrap: "suggestions", Response: extract.Suggestions{}},
+	{Method: http.MethodGet, Path: "/v1/admin/suggest/article/:id/courses", Tag: "admin", Summary: "Suggest the courses an article reports on",
+		Auth: true, Permission: permEditArticles, Wrap: "suggestions", Response: []extract.CourseMatch{}},
+	{Method: http.MethodPost, Path: "/v1/admin/import/:kind", Tag: "admin", Summary: "Import universities or courses from csv",
+		Auth: true, Permission: permImport, Form: []apiParam{{Name: "file", Type: "file", Required: true}, {Name: "dryRun", Type: "boolean"}},
+		Wrap: "report", Response: importer.Report{}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/daad/syncs", Tag: "admin", Summary: "DAAD snapshot syncs",
+		Auth: true, Permission: permSyncDaad, Wrap: "syncs", Response: []*models.DaadSync{}},
+	{Method: http.MethodPost, Path: "/v1/admin/daad/syncs", Tag: "admin", Summary: "Diff a DAAD snapshot against the catalogue",
+		Auth: true, Permission: permSyncDaad, Form: []apiParam{{Name: "snapshot", Type: "string", Required: true}}, Wrap: "sync", Response: models.DaadSync{}},
+	{Method: http.MethodGet, Path: "/v1/admin/daad/syncs/:id", Tag: "admin", Summary: "One DAAD sync with its diff",
+		Auth: true, Permission: permSyncDaad, Wrap: "sync", Response: models.DaadSync{}},
+	{Method: http.MethodPost, Path: "/v1/admin/daad/syncs/:id/apply", Tag: "admin", Summary: "Apply a pending DAAD sync",
+		Auth: true, Permission: permSyncDaad, Wrap: "sync", Response: models.DaadSync{}},
+	{Method: http.MethodPost, Path: "/v1/admin/daad/syncs/:id/reject", Tag: "admin", Summary: "Reject a pending DAAD sync",
+		Auth: true, Permission: permSyncDaad, Wrap: "sync", Response: models.DaadSync{}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/links/broken", Tag: "admin", Summary: "Links whose last check failed",
+		Auth: true, Permission: permViewLinks, Wrap: "links", Response: []models.BrokenLink{}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/drafts", Tag: "admin", Summary: "Ingested article drafts",
+		Auth: true, Permission: permEditArticles, Query: statusQuery, Wrap: "drafts", Response: []*models.ContentDraft{}},
+	{Method: http.MethodPost, Path: "/v1/admin/drafts", Tag: "admin", Summary: "Ingest a saved article page as a draft",
+		Auth: true, Permission: permEditArticles, Form: []apiParam{
+			{Name: "page", Type: "file", Required: true}, {Name: "link", Type: "string"}, {Name: "source", Type: "string"},
+		}, Wrap: "draft", Response: models.ContentDraft{}},
+	{Method: http.MethodGet, Path: "/v1/admin/drafts/:id", Tag: "admin", Summary: "One draft",
+		Auth: true, Permission: permEditArticles, Wrap: "draft", Response: models.ContentDraft{}},
+	{Method: http.MethodPost, Path: "/v1/admin/drafts/:id/approve", Tag: "admin", Summary: "Turn a draft into content, empty fields are taken from the draft",
+		Auth: true, Permission: permEditArticles, Form: contentForm, Wrap: "response", Response: approvedResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/drafts/:id/reject", Tag: "admin", Summary: "Reject a draft",
+		Auth: true, Permission: permEditArticles, Wrap: "response", Response: jsonResp{}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/submissions", Tag: "admin", Summary: "Reader submissions",
+		Auth: true, Permission: permModerate, Query: statusQuery, Wrap: "submissions", Response: []*models.Submission{}},
+	{Method: http.MethodGet, Path: "/v1/admin/submissions/:id", Tag: "admin", Summary: "One submission",
+		Auth: true, Permission: permModerate, Wrap: "submission", Response: models.Submission{}},
+	{Method: http.MethodPost, Path: "/v1/admin/submissions/:id", Tag: "admin", Summary: "Edit a pending submission",
+		Auth: true, Permission: permModerate, Form: concatParams(submissionForm, reviewForm), Wrap: "submission", Response: models.Submission{}},
+	{Method: http.MethodPost, Path: "/v1/admin/submissions/:id/approve", Tag: "admin", Summary: "Publish a submission as content and course results",
+		Auth: true, Permission: permModerate, Form: reviewForm, Wrap: "response", Response: approvedResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/submissions/:id/reject", Tag: "admin", Summary: "Reject a submission",
+		Auth: true, Permission: permModerate, Form: reviewForm, Wrap: "response", Response: jsonResp{}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/users", Tag: "admin", Summary: "Accounts with their roles",
+		Auth: true, Permission: permManageUsers, Wrap: "users", Response: []*models.UserAccount{}},
+	{Method: http.MethodPost, Path: "/v1/admin/users/:id/roles", Tag: "admin", Summary: "Replace the roles of an account",
+		Auth: true, Permission: permManageUsers, Body: rolesRequest{}, BodyRequired: []string{"roles"}, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodGet, Path: "/v1/admin/lockouts", Tag: "admin", Summary: "Failed sign ins per ip and account",
+		Auth: true, Permission: permManageUsers, Wrap: "lockouts", Response: []signInAttempts{}},
+	{Method: http.MethodPost, Path: "/v1/admin/lockouts/clear", Tag: "admin", Summary: "Forget the failed sign ins of an ip or account",
+		Auth: true, Permission: permManageUsers, Body: lockoutRequest{}, BodyRequired: []string{"kind", "key"}, Wrap: "response", Response: jsonResp{}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/apikeys", Tag: "admin", Summary: "Issued API keys",
+		Auth: true, Permission: permManageAPIKeys, Wrap: "api_keys", Response: []*models.APIKey{}},
+	{Method: http.MethodPost, Path: "/v1/admin/apikeys", Tag: "admin", Summary: "Issue an API key, the key is only returned once",
+		Auth: true, Permission: permManageAPIKeys, Body: apiKeyRequest{}, BodyRequired: []string{"name"}, Status: http.StatusCreated, Wrap: "api_key", Response: issuedAPIKey{}},
+	{Method: http.MethodGet, Path: "/v1/admin/apikeys/:id", Tag: "admin", Summary: "One API key with its usage per endpoint",
+		Auth: true, Permission: permManageAPIKeys, Query: []apiParam{{Name: "days", Type: "integer"}}, Wrap: "api_key", Response: models.APIKey{}},
+	{Method: http.MethodPost, Path: "/v1/admin/apikeys/:id/suspend", Tag: "admin", Summary: "Suspend an API key",
+		Auth: true, Permission: permManageAPIKeys, Wrap: "response", Response: jsonResp{}},
+	{Method: http.MethodPost, Path: "/v1/admin/apikeys/:id/activate", Tag: "admin", Summary: "Reactivate an API key",
+		Auth: true, Permission: permManageAPIKeys, Wrap: "response", Response: jsonResp{}},
+}
+
+func concatParams(lists ...[]apiParam) []apiParam {
+	var params []apiParam
+	for _, l := range lists {
+		params = append(params, l...)
+	}
+	return params
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIJSON []byte
+)
+
+// getOpenAPI serves the OpenAPI document built from apiRoutes
+func (app *application) getOpenAPI(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		openAPIJSON, _ = json.MarshalIndent(openAPIDocument(), "", "  ")
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPIJSON)
+}
+
+type jsonObject = map[string]interface{}
+
+func openAPIDocument() jsonObject {
+	s := &schemaBuilder{components: jsonObject{}}
+
+	errorSchema := jsonObject{
+		"type": "object",
+		"properties": jsonObject{"error": jsonObject{
+			"type":       "object",
+			"properties": jsonObject{"message": jsonObject{"type": "string"}},
+		}},
+	}
+	s.components["Error"] = errorSchema
+	metaData := s.schema(reflect.TypeOf(MetaData{}))
+
+	paths := jsonObject{}
+	for _, route := range apiRoutes {
+		path, params := openAPIPath(route.Path)
+
+		op := jsonObject{
+			"summary": route.Summary,
+			"tags":    []string{route.Tag},
+		}
+
+		for _, p := range route.Query {
+			params = append(params, parameter("query", p))
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if route.Body != nil {
+			body := s.schema(reflect.TypeOf(route.Body))
+			op["requestBody"] = jsonObject{
+				"required": len(route.BodyRequired) > 0,
+				"content":  jsonObject{"application/json": jsonObject{"schema": withRequired(body, route.BodyRequired)}},
+			}
+		}
+
+		if len(route.Form) > 0 {
+			props := jsonObject{}
+			var required []string
+			for _, f := range route.Form {
+				props[f.Name] = paramSchema(f)
+				if f.Required {
+					required = append(required, f.Name)
+				}
+			}
+			form := jsonObject{"type": "object", "properties": props}
+			if len(required) > 0 {
+				form["required"] = required
+			}
+			op["requestBody"] = jsonObject{
+				"content": jsonObject{"multipart/form-data": jsonObject{"schema": form}},
+			}
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		ok := jsonObject{"description": http.StatusText(status)}
+		switch {
+		case len(route.Raw) > 0:
+			content := jsonObject{}
+			for _, ct := range route.Raw {
+				schema := jsonObject{"type": "string", "format": "binary"}
+				if route.Response != nil {
+					schema = s.schema(reflect.TypeOf(route.Response))
+				} else if strings.Contains(ct, "json") {
+					schema = jsonObject{"type": "object"}
+				}
+				content[ct] = jsonObject{"schema": schema}
+			}
+			ok["content"] = content
+		case route.Wrap != "":
+			ok["content"] = jsonObject{"application/json": jsonObject{"schema": jsonObject{
+				"type":       "object",
+				"properties": jsonObject{route.Wrap: s.schema(reflect.TypeOf(route.Response))},
+			}}}
+		}
+		if route.Paginated {
+			ok["headers"] = jsonObject{"Pagination": jsonObject{
+				"description": "JSON encoded MetaData",
+				"schema":      jsonObject{"type": "string"},
+				"example":     metaData,
+			}}
+		}
+
+		op["responses"] = jsonObject{
+			strconv.Itoa(status): ok,
+			"default": jsonObject{
+				"description": "Error",
+				"content":     jsonObject{"application/json": jsonObject{"schema": jsonObject{"$ref": "#/components/schemas/Error"}}},
+			},
+		}
+
+		switch {
+		case route.Auth:
+			op["security"] = []jsonObject{{"bearerAuth": []string{}}}
+			if route.Permission != "" {
+				op["x-permission"] = route.Permission
+				op["description"] = "Requires the " + route.Permission + " permission."
+			}
+		case route.APIKey:
+			op["security"] = []jsonObject{{}, {"apiKey": []string{}}}
+		}
+
+		item, _ := paths[path].(jsonObject)
+		if item == nil {
+			item = jsonObject{}
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = op
+	}
+
+	return jsonObject{
+		"openapi": "3.0.3",
+		"info": jsonObject{
+			"title":   "Go Germany API",
+			"version": version,
+		},
+		"paths": paths,
+		"components": jsonObject{
+			"schemas": s.components,
+			"securitySchemes": jsonObject{
+				"bearerAuth": jsonObject{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"apiKey":     jsonObject{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+	}
+}
+
+// openAPIPath converts an httprouter pattern to an OpenAPI path and its parameters
+func openAPIPath(pattern string) (string, []jsonObject) {
+	var params []jsonObject
+
+	parts := strings.Split(pattern, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			name := part[1:]
+			parts[i] = "{" + name + "}"
+			params = append(params, parameter("path", pathParams[name]))
+		}
+	}
+
+	return strings.Join(parts, "/"), params
+}
+
+func parameter(in string, p apiParam) jsonObject {
+	schema := paramSchema(p)
+	delete(schema, "description")
+
+	param := jsonObject{
+		"name":     p.Name,
+		"in":       in,
+		"required": p.Required,
+		"schema":   schema,
+	}
+	if p.Description != "" {
+		param["description"] = p.Description
+	}
+	return param
+}
+
+func paramSchema(p apiParam) jsonObject {
+	schema := jsonObject{"type": p.Type}
+	if p.Type == "file" {
+		schema = jsonObject{"type": "string", "format": "binary"}
+	}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+	if p.Description != "" {
+		schema["description"] = p.Description
+	}
+	return schema
+}
+
+func withRequired(schema jsonObject, required []string) jsonObject {
+	if len(required) == 0 {
+		return schema
+	}
+	return jsonObject{"allOf": []jsonObject{schema, {"required": required}}}
+}
+
+// schemaBuilder derives json schemas from Go types, collecting named
+// structs as components
+type schemaBuilder struct {
+	components jsonObject
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (s *schemaBuilder) schema(t reflect.Type) jsonObject {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return s.schema(t.Elem())
+	case reflect.Bool:
+		return jsonObject{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return jsonObject{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return jsonObject{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return jsonObject{"type": "number"}
+	case reflect.String:
+		return jsonObject{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return jsonObject{"type": "string", "format": "byte"}
+		}
+		return jsonObject{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return jsonObject{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return jsonObject{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		if _, ok := s.components[t.Name()]; !ok {
+			s.components[t.Name()] = jsonObject{}
+			s.components[t.Name()] = s.object(t)
+		}
+		return jsonObject{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return jsonObject{}
+	}
+}
+
+func (s *schemaBuilder) object(t reflect.Type) jsonObject {
+	props := jsonObject{}
+	s.fields(t, props)
+
+	return jsonObject{"type": "object", "properties": props}
+}
+
+func (s *schemaBuilder) fields(t reflect.Type, props jsonObject) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			s.fields(ft, props)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		props[name] = s.schema(f.Type)
+	}
+}
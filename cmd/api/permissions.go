@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Permissions checked by the admin routes
+const (
+	permEditCourses      = "courses:write"
+	permEditUniversities = "universities:write"
+	permEditArticles     = "articles:write"
+	permModerate         = "submissions:moderate"
+	permImport           = "import:write"
+	permSyncDaad         = "daad:sync"
+	permViewLinks        = "links:read"
+	permManageUsers      = "users:manage"
+	permManageAPIKeys    = "apikeys:manage"
+)
+
+// rolePermissions maps every role to the permissions it grants
+var rolePermissions = map[string][]string{
+	"admin": {
+		permEditCourses, permEditUniversities, permEditArticles, permModerate, permImport,
+		permSyncDaad, permViewLinks, permManageUsers, permManageAPIKeys,
+	},
+	"editor": {
+		permEditCourses, permEditArticles, permViewLinks,
+	},
+	"moderator": {
+		permModerate,
+	},
+	"reader": {},
+}
+
+// validRole reports whether role is known
+func validRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// permissionsOf returns the sorted union of the permissions of roles
+func permissionsOf(roles []string) []string {
+	set := make(map[string]bool)
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			set[p] = true
+		}
+	}
+
+	perms := make([]string, 0, len(set))
+	for p := range set {
+		perms = append(perms, p)
+	}
+	sort.Strings(perms)
+	return perms
+}
+
+// hasPermission reports whether any of roles grants perm
+func hasPermission(roles []string, perm string) bool {
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requirePermission rejects requests whose token roles do not grant perm. It
+// must run after checkToken.
+func (app *application) requirePermission(perm string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasPermission(userRoles(r), perm) {
+				app.errorJSON(w, fmt.Errorf("forbidden - missing permission %s", perm), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
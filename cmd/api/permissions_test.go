@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		roles []string
+		perm  string
+		want  bool
+	}{
+		{[]string{"admin"}, permManageUsers, true},
+		{[]string{"admin"}, permManageAPIKeys, true},
+		{[]string{"editor"}, permEditCourses, true},
+		{[]string{"editor"}, permEditArticles, true},
+		{[]string{"editor"}, permViewLinks, true},
+		{[]string{"editor"}, permEditUniversities, false},
+		{[]string{"editor"}, permManageUsers, false},
+		{[]string{"moderator"}, permModerate, true},
+		{[]string{"moderator"}, permEditCourses, false},
+		{[]string{"reader"}, permViewLinks, false},
+		{[]string{"moderator", "editor"}, permModerate, true},
+		{[]string{"moderator", "editor"}, permEditCourses, true},
+		{[]string{"unknown"}, permModerate, false},
+		{nil, permViewLinks, false},
+	}
+
+	for _, tt := range tests {
+		if got := hasPermission(tt.roles, tt.perm); got != tt.want {
+			t.Errorf("hasPermission(%v, %s) = %v, want %v", tt.roles, tt.perm, got, tt.want)
+		}
+	}
+
+	// admin grants whatever any other role does
+	for role, perms := range rolePermissions {
+		for _, perm := range perms {
+			if !hasPermission([]string{"admin"}, perm) {
+				t.Errorf("admin lacks %s of %s", perm, role)
+			}
+		}
+	}
+}
+
+func TestPermissionsOf(t *testing.T) {
+	got := permissionsOf([]string{"moderator", "editor", "moderator"})
+	want := []string{permEditArticles, permEditCourses, permViewLinks, permModerate}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := permissionsOf([]string{"reader"}); len(got) != 0 {
+		t.Errorf("reader: got %v, want no permissions", got)
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.requirePermission(permModerate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		roles []string
+		want  int
+	}{
+		{[]string{"moderator"}, http.StatusNoContent},
+		{[]string{"admin"}, http.StatusNoContent},
+		{[]string{"editor"}, http.StatusForbidden},
+		{nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), userRolesKey, tt.roles))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		if rec.Code != tt.want {
+			t.Errorf("%v: got %d %s, want %d", tt.roles, rec.Code, rec.Body, tt.want)
+		}
+		if tt.want == http.StatusForbidden && !strings.Contains(rec.Body.String(), permModerate) {
+			t.Errorf("%v: got %s, want the missing permission named", tt.roles, rec.Body)
+		}
+	}
+}
+
+func TestSetUserRolesRefusesSelfDemotion(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"roles": ["editor", "moderator"]}`, "cannot remove your own permission to manage users"},
+		{`{"roles": []}`, "cannot remove your own permission to manage users"},
+		{`{"roles": ["owner"]}`, `unknown role \"owner\"`},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/v1/admin/users/7/roles", strings.NewReader(tt.body))
+		ctx := context.WithValue(r.Context(), userIDKey, 7)
+		ctx = context.WithValue(ctx, httprouter.ParamsKey, httprouter.Params{{Key: "id", Value: "7"}})
+		rec := httptest.NewRecorder()
+		app.setUserRoles(rec, r.WithContext(ctx))
+
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%s: got %d %s, want 400 %q", tt.body, rec.Code, rec.Body, tt.want)
+		}
+	}
+}
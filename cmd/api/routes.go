@@ -10,33 +10,115 @@ import (
 
 func (app *application) wrap(next http.Handler) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-		ctx := context.WithValue(r.Context(), "params", ps)
+		ctx := context.WithValue(r.Context(), httprouter.ParamsKey, ps)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 }
 
 func (app *application) routes() http.Handler {
-	router := httprouter.New()
+	return app.enableCORS(app.conditionalGET(app.router()))
+}
+
+// router registers every route. routes_test.go checks that they match apiRoutes.
+func (app *application) router() *apiRouter {
+	router := app.newRouter()
 	secure := alice.New(app.checkToken)
 
 	router.HandlerFunc(http.MethodGet, "/status", app.statusHandler)
+	router.HandlerFunc(http.MethodGet, "/livez", app.getLivez)
+	router.HandlerFunc(http.MethodGet, "/readyz", app.getReadyz)
+
+	metrics := app.metricsHandler()
+	if app.config.metrics.addr != "" {
+		// served by serveMetrics instead
+		metrics = http.NotFoundHandler()
+	}
+	router.Handler(http.MethodGet, "/metrics", metrics)
+	router.HandlerFunc(http.MethodGet, "/.well-known/jwks.json", app.getJWKS)
+	router.HandlerFunc(http.MethodGet, "/v1/openapi.json", app.getOpenAPI)
 
 	router.HandlerFunc(http.MethodPost, "/v1/account/signin", app.signIn)
+	router.HandlerFunc(http.MethodPost, "/v1/account/refresh", app.refreshToken)
+	router.HandlerFunc(http.MethodPost, "/v1/account/logout", app.logout)
+	router.HandlerFunc(http.MethodPost, "/v1/account/register", app.register)
+	router.HandlerFunc(http.MethodPost, "/v1/account/verify", app.verifyEmail)
+	router.HandlerFunc(http.MethodPost, "/v1/account/verify/resend", app.resendVerification)
+	router.HandlerFunc(http.MethodPost, "/v1/account/password/forgot", app.forgotPassword)
+	router.HandlerFunc(http.MethodPost, "/v1/account/password/reset", app.resetPassword)
+
+	app.public(router, "/v1/course/:id", listingCache, app.getOneCourse)
+	app.public(router, "/v1/courses", listingCache, app.getAllCourses)
+	app.public(router, "/v1/courses/filters", filterCache, app.getFilters)
+	app.public(router, "/v1/courses/compare", listingCache, app.compareCourses)
+	app.public(router, "/v1/courses/export", noCache, app.exportCourses)
+
+	app.public(router, "/v1/article/:id", listingCache, app.getOneArticle)
+	app.public(router, "/v1/articles", listingCache, app.getAllArticles)
+	app.public(router, "/v1/articles/filters", filterCache, app.getArticleFilters)
+	app.public(router, "/v1/articles/export", noCache, app.exportArticles)
+
+	app.public(router, "/v1/graphql", noCache, app.graphQL)
+	router.Handler(http.MethodPost, "/v1/graphql", app.apiKey("/v1/graphql", http.HandlerFunc(app.graphQL)))
+
+	router.HandlerFunc(http.MethodPost, "/v1/submissions", app.createSubmission)
 
-	router.HandlerFunc(http.MethodGet, "/v1/course/:id", app.getOneCourse)
-	router.HandlerFunc(http.MethodGet, "/v1/courses", app.getAllCourses)
-	router.HandlerFunc(http.MethodGet, "/v1/courses/filters", app.getFilters)
+	// requests are validated only once the token and the permission are checked
+	admin := func(perm string) alice.Chain {
+		return secure.Append(app.requirePermission(perm), app.validated)
+	}
+
+	editCourses := admin(permEditCourses).Append(app.invalidateCache)
+	editUniversities := admin(permEditUniversities).Append(app.invalidateCache)
+	editArticles := admin(permEditArticles).Append(app.invalidateCache)
+	moderate := admin(permModerate).Append(app.invalidateCache)
+	importRows := admin(permImport).Append(app.invalidateCache)
+	syncDaad := admin(permSyncDaad).Append(app.invalidateCache)
+	viewLinks := admin(permViewLinks)
+	manageUsers := admin(permManageUsers)
+	manageAPIKeys := admin(permManageAPIKeys)
 
-	router.HandlerFunc(http.MethodGet, "/v1/article/:id", app.getOneArticle)
-	router.HandlerFunc(http.MethodGet, "/v1/articles", app.getAllArticles)
-	router.HandlerFunc(http.MethodGet, "/v1/articles/filters", app.getArticleFilters)
+	router.GET("/v1/account", app.wrap(secure.Append(app.validated).ThenFunc(app.getAccount)))
 
-	router.POST("/v1/admin/editcourse", app.wrap(secure.ThenFunc(app.editCourse)))
+	router.POST("/v1/admin/editcourse", app.wrap(editCourses.ThenFunc(app.editCourse)))
 	// router.HandlerFunc(http.MethodPost, "/v1/admin/editcourse", app.editCourse)
 
-	router.POST("/v1/admin/edituniversity", app.wrap(secure.ThenFunc(app.editUniversity)))
-	router.POST("/v1/admin/editcontent", app.wrap(secure.ThenFunc(app.editContent)))
-	router.POST("/v1/admin/editarticle", app.wrap(secure.ThenFunc(app.editArticle)))
+	router.POST("/v1/admin/edituniversity", app.wrap(editUniversities.ThenFunc(app.editUniversity)))
+	router.POST("/v1/admin/editcontent", app.wrap(editArticles.ThenFunc(app.editContent)))
+	router.POST("/v1/admin/editarticle", app.wrap(editArticles.ThenFunc(app.editArticle)))
+	router.POST("/v1/admin/suggest/content", app.wrap(editArticles.ThenFunc(app.suggestContent)))
+	router.GET("/v1/admin/suggest/article/:id/courses", app.wrap(editArticles.ThenFunc(app.suggestArticleCourses)))
+	router.POST("/v1/admin/import/:kind", app.wrap(importRows.ThenFunc(app.importRows)))
+
+	router.GET("/v1/admin/daad/syncs", app.wrap(syncDaad.ThenFunc(app.getDaadSyncs)))
+	router.POST("/v1/admin/daad/syncs", app.wrap(syncDaad.ThenFunc(app.createDaadSync)))
+	router.GET("/v1/admin/daad/syncs/:id", app.wrap(syncDaad.ThenFunc(app.getDaadSync)))
+	router.POST("/v1/admin/daad/syncs/:id/apply", app.wrap(syncDaad.ThenFunc(app.applyDaadSync)))
+	router.POST("/v1/admin/daad/syncs/:id/reject", app.wrap(syncDaad.ThenFunc(app.rejectDaadSync)))
+
+	router.GET("/v1/admin/links/broken", app.wrap(viewLinks.ThenFunc(app.getBrokenLinks)))
+
+	router.GET("/v1/admin/drafts", app.wrap(editArticles.ThenFunc(app.getDrafts)))
+	router.POST("/v1/admin/drafts", app.wrap(editArticles.ThenFunc(app.ingestDraft)))
+	router.GET("/v1/admin/drafts/:id", app.wrap(editArticles.ThenFunc(app.getDraft)))
+	router.POST("/v1/admin/drafts/:id/approve", app.wrap(editArticles.ThenFunc(app.approveDraft)))
+	router.POST("/v1/admin/drafts/:id/reject", app.wrap(editArticles.ThenFunc(app.rejectDraft)))
+
+	router.GET("/v1/admin/submissions", app.wrap(moderate.ThenFunc(app.getSubmissions)))
+	router.GET("/v1/admin/submissions/:id", app.wrap(moderate.ThenFunc(app.getSubmission)))
+	router.POST("/v1/admin/submissions/:id", app.wrap(moderate.ThenFunc(app.editSubmission)))
+	router.POST("/v1/admin/submissions/:id/approve", app.wrap(moderate.ThenFunc(app.approveSubmission)))
+	router.POST("/v1/admin/submissions/:id/reject", app.wrap(moderate.ThenFunc(app.rejectSubmission)))
+
+	router.GET("/v1/admin/users", app.wrap(manageUsers.ThenFunc(app.getUsers)))
+	router.POST("/v1/admin/users/:id/roles", app.wrap(manageUsers.ThenFunc(app.setUserRoles)))
+	router.GET("/v1/admin/lockouts", app.wrap(manageUsers.ThenFunc(app.getLockouts)))
+	router.POST("/v1/admin/lockouts/clear", app.wrap(manageUsers.ThenFunc(app.clearLockout)))
+
+	router.GET("/v1/admin/apikeys", app.wrap(manageAPIKeys.ThenFunc(app.getAPIKeys)))
+	router.POST("/v1/admin/apikeys", app.wrap(manageAPIKeys.ThenFunc(app.createAPIKey)))
+	router.GET("/v1/admin/apikeys/:id", app.wrap(manageAPIKeys.ThenFunc(app.getAPIKey)))
+	router.POST("/v1/admin/apikeys/:id/suspend", app.wrap(manageAPIKeys.ThenFunc(app.suspendAPIKey)))
+	router.POST("/v1/admin/apikeys/:id/activate", app.wrap(manageAPIKeys.ThenFunc(app.activateAPIKey)))
 
-	return app.enableCORS(router)
+	return router
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRoutesMatchSpec(t *testing.T) {
+	app := newTestApplication(t)
+
+	err := app.router().check()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckReportsUndocumentedRoutes(t *testing.T) {
+	app := newTestApplication(t)
+
+	router := app.router()
+	router.HandlerFunc(http.MethodGet, "/v1/undocumented", app.statusHandler)
+
+	err := router.check()
+	if err == nil || !strings.Contains(err.Error(), "undocumented route GET /v1/undocumented") {
+		t.Errorf("got %v, want the undocumented route reported", err)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.routes()
+
+	multipartBody := func(fields map[string]string) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		for k, v := range fields {
+			mw.WriteField(k, v)
+		}
+		mw.Close()
+		return &body, mw.FormDataContentType()
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        func() (*bytes.Buffer, string)
+		want        string
+	}{
+		{name: "integer path parameter", method: http.MethodGet, target: "/v1/course/abc", want: "id must be an integer"},
+		{name: "integer query parameter", method: http.MethodGet, target: "/v1/courses?pageNumber=x&pageSize=10", want: "pageNumber must be an integer"},
+		{name: "required query parameter", method: http.MethodGet, target: "/v1/courses?pageSize=10", want: "pageNumber is required"},
+		{name: "enum query parameter", method: http.MethodGet, target: "/v1/courses/export?format=pdf", want: "format must be one of csv, xlsx"},
+		{name: "required body field", method: http.MethodPost, target: "/v1/account/register", contentType: "application/json",
+			body: func() (*bytes.Buffer, string) { return bytes.NewBufferString(`{"email": "a@example.com"}`), "" }, want: "password is required"},
+		{name: "body field type without required fields", method: http.MethodPost, target: "/v1/account/refresh", contentType: "application/json",
+			body: func() (*bytes.Buffer, string) { return bytes.NewBufferString(`{"refresh_token": 1}`), "" }, want: "refresh_token has the wrong type"},
+		{name: "multipart form field type", method: http.MethodPost, target: "/v1/submissions",
+			body: func() (*bytes.Buffer, string) { return multipartBody(map[string]string{"courseId": "x"}) }, want: "courseId must be an integer"},
+		{name: "url encoded form field enum", method: http.MethodPost, target: "/v1/submissions", contentType: "application/x-www-form-urlencoded",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(url.Values{"result": {"Maybe"}}.Encode()), ""
+			}, want: "result must be one of Admission, Rejection"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			contentType := tt.contentType
+			if tt.body != nil {
+				b, ct := tt.body()
+				body = *b
+				if ct != "" {
+					contentType = ct
+				}
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, &body)
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("got %d %s, want 400 %q", rec.Code, rec.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAfterAuth(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.routes()
+
+	// callers without a token learn nothing about the fields of routes needing one
+	for _, route := range apiRoutes {
+		if !route.Auth {
+			continue
+		}
+
+		target := strings.ReplaceAll(route.Path, ":id", "abc")
+		t.Run(route.Method+" "+target, func(t *testing.T) {
+			req := httptest.NewRequest(route.Method, target, strings.NewReader(`{"unknown": 1}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if !strings.Contains(rec.Body.String(), "invalid auth header") {
+				t.Errorf("got %d %s, want the missing token reported", rec.Code, rec.Body)
+			}
+		})
+	}
+}
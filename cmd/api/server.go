@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// worker is a background job run next to the server. It returns once ctx is
+// done and gives up on whatever it still waits for when expired is closed.
+type worker func(ctx context.Context, expired <-chan struct{})
+
+// untilDone adapts a job that only needs to stop once ctx is done
+func untilDone(job func(context.Context)) worker {
+	return func(ctx context.Context, _ <-chan struct{}) { job(ctx) }
+}
+
+// backgroundWorkers returns the jobs of the configuration run by serve
+func (app *application) backgroundWorkers() []worker {
+	var workers []worker
+	if app.config.grpcPort > 0 {
+		workers = append(workers, app.serveGRPC)
+	}
+	if app.config.metrics.addr != "" {
+		workers = append(workers, untilDone(app.serveMetrics))
+	}
+	workers = append(workers, untilDone(app.listenForChanges))
+	if app.config.linkcheck.interval > 0 {
+		workers = append(workers, untilDone(app.runLinkChecker))
+	}
+	return workers
+}
+
+// serve runs srv on lis and the workers until ctx is done. It then marks
+// the instance not ready, stops accepting connections and waits up to the
+// shutdown timeout for in-flight requests, the workers and pending mails
+// to finish. Whatever happens, it returns only after the workers did.
+func (app *application) serve(ctx context.Context, srv *http.Server, lis net.Listener, workers []worker) error {
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	// closed once the shutdown timeout is over, workers that cannot be
+	// cancelled through their context stop waiting then
+	expired, expire := context.WithCancel(context.Background())
+	defer expire()
+
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+		go func(w worker) {
+			defer wg.Done()
+			w(workersCtx, expired.Done())
+		}(w)
+	}
+
+	done := make(chan struct{})
+	wait := func() {
+		wg.Wait()
+		app.mails.Wait()
+		close(done)
+	}
+
+	listenErr := make(chan error, 1)
+	go func() {
+		app.logger.Info("starting server", "addr", lis.Addr().String(), "env", app.config.env)
+		err := srv.Serve(lis)
+		if !errors.Is(err, http.ErrServerClosed) {
+			listenErr <- err
+		}
+	}()
+
+	select {
+	case err := <-listenErr:
+		// nothing is served, so the workers get no time to finish
+		stopWorkers()
+		expire()
+		wait()
+		return err
+	case <-ctx.Done():
+	}
+
+	app.logger.Info("shutting down", "drain_delay", app.config.shutdown.drainDelay, "timeout", app.config.shutdown.timeout)
+
+	// give the load balancer time to see /readyz fail before the listener closes
+	app.health.draining.Store(true)
+	time.Sleep(app.config.shutdown.drainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), app.config.shutdown.timeout)
+	defer cancel()
+	context.AfterFunc(shutdownCtx, expire)
+
+	stopWorkers()
+	go wait()
+
+	err := srv.Shutdown(shutdownCtx)
+	if err != nil {
+		expire()
+	}
+
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		// the workers were told to give up, they return shortly
+		<-done
+		if err == nil {
+			err = errors.New("background workers did not stop in time")
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	app.logger.Info("stopped")
+	return nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// startServe runs serve for app on a free local port with the slow route
+// added. started is closed once a request reached the slow route.
+func startServe(t *testing.T, app *application, slow time.Duration, workers []worker) (string, context.CancelFunc, <-chan struct{}, <-chan error) {
+	t.Helper()
+
+	connector, err := models.NewConnector("postgres://nobody@127.0.0.1:1/none?sslmode=disable&connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+	app.models = models.NewModels(db)
+
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.Handle("/", app.routes())
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(slow)
+		w.Write([]byte("done"))
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- app.serve(ctx, &http.Server{Handler: mux}, lis, workers)
+	}()
+
+	return "http://" + lis.Addr().String(), cancel, started, served
+}
+
+func TestServeDrains(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.shutdown.drainDelay = 300 * time.Millisecond
+	app.config.shutdown.timeout = 5 * time.Second
+
+	var workerStopped atomic.Bool
+	slowWorker := func(ctx context.Context, _ <-chan struct{}) {
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+		workerStopped.Store(true)
+	}
+
+	url, cancel, started, served := startServe(t, app, 600*time.Millisecond, []worker{slowWorker})
+
+	type result struct {
+		body string
+		err  error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		slow <- result{body: string(b), err: err}
+	}()
+	<-started
+
+	cancel()
+
+	// the listener stays open during the drain delay while /readyz fails
+	deadline := time.Now().Add(app.config.shutdown.drainDelay)
+	for {
+		resp, err := http.Get(url + "/readyz")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body struct {
+			Readiness healthReport `json:"readiness"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode == http.StatusServiceUnavailable && body.Readiness.Checks["draining"].Status == "fail" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("readyz did not report draining, got %d %+v", resp.StatusCode, body.Readiness)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	r := <-slow
+	if r.err != nil || r.body != "done" {
+		t.Errorf("in-flight request: got %q, %v", r.body, r.err)
+	}
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return")
+	}
+
+	if !workerStopped.Load() {
+		t.Error("serve returned before the worker stopped")
+	}
+}
+
+func TestServeShutdownDeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		slow    time.Duration
+		request bool
+		want    error
+	}{
+		{name: "in-flight request", slow: 5 * time.Second, request: true, want: context.DeadlineExceeded},
+		{name: "worker", want: errors.New("background workers did not stop in time")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication(t)
+			app.config.shutdown.timeout = 200 * time.Millisecond
+
+			// a worker that only stops once the shutdown timeout is over and
+			// then takes a while to clean up
+			var returned atomic.Bool
+			stuck := func(ctx context.Context, expired <-chan struct{}) {
+				<-expired
+				time.Sleep(100 * time.Millisecond)
+				returned.Store(true)
+			}
+
+			url, cancel, started, served := startServe(t, app, tt.slow, []worker{stuck})
+			if tt.request {
+				go http.Get(url + "/slow")
+				<-started
+			}
+
+			start := time.Now()
+			cancel()
+
+			select {
+			case err := <-served:
+				if err == nil || err.Error() != tt.want.Error() {
+					t.Errorf("got %v, want %v", err, tt.want)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("serve did not return")
+			}
+
+			if elapsed := time.Since(start); elapsed < app.config.shutdown.timeout || elapsed > time.Second {
+				t.Errorf("serve returned after %s, want about %s", elapsed, app.config.shutdown.timeout)
+			}
+
+			if !returned.Load() {
+				t.Error("serve returned before the worker did")
+			}
+		})
+	}
+}
+
+func TestServeListenError(t *testing.T) {
+	app := newTestApplication(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis.Close()
+
+	var returned atomic.Bool
+	stuck := func(ctx context.Context, expired <-chan struct{}) {
+		<-expired
+		time.Sleep(100 * time.Millisecond)
+		returned.Store(true)
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- app.serve(context.Background(), &http.Server{}, lis, []worker{stuck})
+	}()
+
+	select {
+	case err := <-served:
+		if err == nil {
+			t.Error("got no error serving on a closed listener")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("serve did not return")
+	}
+
+	if !returned.Load() {
+		t.Error("serve returned before the worker did")
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// signInLimit configures the backoff of one kind of sign in key. The first
+// free failures are not delayed, each further failure doubles the wait from
+// base up to max, and lockAfter failures lock the key for lockout.
+type signInLimit struct {
+	free      int
+	base      time.Duration
+	max       time.Duration
+	lockAfter int
+	lockout   time.Duration
+}
+
+var (
+	accountLimit = signInLimit{free: 3, base: time.Second, max: 5 * time.Minute, lockAfter: 10, lockout: 15 * time.Minute}
+	ipLimit      = signInLimit{free: 10, base: time.Second, max: 5 * time.Minute, lockAfter: 50, lockout: time.Hour}
+)
+
+// failures are forgotten after this long without another failure
+const signInWindow = 24 * time.Hour
+
+type signInAttempts struct {
+	Kind     string    `json:"kind"`
+	Key      string    `json:"key"`
+	Failures int       `json:"failures"`
+	Last     time.Time `json:"last_failure"`
+	Until    time.Time `json:"blocked_until"`
+	Locked   bool      `json:"locked"`
+}
+
+// signInGuard tracks failed sign ins per client ip and per account in memory
+type signInGuard struct {
+	mu       sync.Mutex
+	attempts map[string]*signInAttempts
+	swept    time.Time
+}
+
+func newSignInGuard() *signInGuard {
+	return &signInGuard{attempts: make(map[string]*signInAttempts)}
+}
+
+// blocked returns how long the ip or account still has to wait before trying
+// again. A locked account stays locked from every ip; its owner gets back in
+// by resetting the password, or an admin clears the lockout.
+func (g *signInGuard) blocked(ip, account string) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	keys := []string{"ip:" + ip, "account:" + account}
+
+	now := time.Now()
+	var wait time.Duration
+	for _, k := range keys {
+		if a, ok := g.attempts[k]; ok && a.Until.After(now) && a.Until.Sub(now) > wait {
+			wait = a.Until.Sub(now)
+		}
+	}
+	return wait
+}
+
+// fail records a failed sign in
+func (g *signInGuard) fail(ip, account string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	g.record(now, "ip", ip, ipLimit)
+	g.record(now, "account", account, accountLimit)
+
+	if now.Sub(g.swept) > time.Minute {
+		for k, a := range g.attempts {
+			if now.Sub(a.Last) > signInWindow && now.After(a.Until) {
+				delete(g.attempts, k)
+			}
+		}
+		g.swept = now
+	}
+}
+
+func (g *signInGuard) record(now time.Time, kind, key string, limit signInLimit) {
+	a, ok := g.attempts[kind+":"+key]
+	if !ok || now.Sub(a.Last) > signInWindow {
+		a = &signInAttempts{Kind: kind, Key: key}
+		g.attempts[kind+":"+key] = a
+	}
+
+	a.Failures++
+	a.Last = now
+
+	switch {
+	case a.Failures >= limit.lockAfter:
+		a.Locked = true
+		a.Until = now.Add(limit.lockout)
+	case a.Failures > limit.free:
+		wait := time.Duration(float64(limit.base) * math.Pow(2, float64(a.Failures-limit.free-1)))
+		if wait > limit.max {
+			wait = limit.max
+		}
+		a.Until = now.Add(wait)
+	}
+}
+
+// succeed forgets the failures of the account
+func (g *signInGuard) succeed(account string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.attempts, "account:"+account)
+}
+
+// list returns the keys with recorded failures, most recent first
+func (g *signInGuard) list() []signInAttempts {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	list := make([]signInAttempts, 0, len(g.attempts))
+	for _, a := range g.attempts {
+		list = append(list, *a)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Last.After(list[j].Last) })
+	return list
+}
+
+// clear forgets the failures of a key and reports whether there were any
+func (g *signInGuard) clear(kind, key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, ok := g.attempts[kind+":"+key]
+	delete(g.attempts, kind+":"+key)
+	return ok
+}
+
+// clientIP returns the address of the client, taken from the last
+// X-Forwarded-For entry when running behind a trusted proxy
+func (app *application) clientIP(r *http.Request) string {
+	if app.config.trustProxy {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			parts := strings.Split(xff, ",")
+			return strings.TrimSpace(parts[len(parts)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (app *application) getLockouts(w http.ResponseWriter, r *http.Request) {
+	err := app.writeJSON(w, http.StatusOK, app.signInGuard.list(), "lockouts")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+type lockoutRequest struct {
+	Kind string `json:"kind"`
+	Key  string `json:"key"`
+}
+
+// clearLockout takes a json body {"kind": "ip"|"account", "key": "..."}
+func (app *application) clearLockout(w http.ResponseWriter, r *http.Request) {
+	var req lockoutRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	if req.Kind != "ip" && req.Kind != "account" {
+		app.errorJSON(w, errors.New("kind must be ip or account"))
+		return
+	}
+
+	key := req.Key
+	if req.Kind == "account" {
+		key = strings.ToLower(strings.TrimSpace(key))
+	}
+
+	if !app.signInGuard.clear(req.Kind, key) {
+		app.errorJSON(w, fmt.Errorf("no failed sign ins recorded for %s %s", req.Kind, key), http.StatusNotFound)
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
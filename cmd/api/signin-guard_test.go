@@ -0,0 +1,69 @@
+package main
+
+import (
+	"backend/models"
+	"database/sql"
+	"net/http"
+	"testing"
+)
+
+func TestSignInGuardAccountLock(t *testing.T) {
+	g := newSignInGuard()
+
+	for i := 0; i < accountLimit.lockAfter; i++ {
+		g.fail("192.0.2.1", "victim@example.com")
+	}
+
+	if g.blocked("192.0.2.1", "victim@example.com") == 0 {
+		t.Error("the failing ip may still try the account")
+	}
+	if g.blocked("192.0.2.2", "victim@example.com") == 0 {
+		t.Error("a fresh ip may still try the locked account")
+	}
+	if g.blocked("192.0.2.2", "someone@example.com") != 0 {
+		t.Error("a fresh ip is blocked on another account")
+	}
+
+	g.succeed("victim@example.com")
+	if g.blocked("192.0.2.2", "victim@example.com") != 0 {
+		t.Error("the account is still locked after its owner got back in")
+	}
+}
+
+func TestSignInGuardIPBackoff(t *testing.T) {
+	g := newSignInGuard()
+
+	for i := 0; i < ipLimit.free; i++ {
+		g.fail("192.0.2.1", "a@example.com")
+		g.succeed("a@example.com")
+	}
+	if g.blocked("192.0.2.1", "b@example.com") != 0 {
+		t.Error("free failures are delayed")
+	}
+
+	g.fail("192.0.2.1", "a@example.com")
+	if g.blocked("192.0.2.1", "b@example.com") == 0 {
+		t.Error("failures beyond the free ones are not delayed")
+	}
+}
+
+func TestSignInDatabaseError(t *testing.T) {
+	app := newTestApplication(t)
+
+	connector, err := models.NewConnector("postgres://nobody@127.0.0.1:1/none?sslmode=disable&connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+	app.models = models.NewModels(db)
+
+	rec := do(t, app.routes(), http.MethodPost, "/v1/account/signin", Credentials{Username: "a@example.com", Password: "password 1"})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("got %d %s, want 500", rec.Code, rec.Body)
+	}
+	if len(app.signInGuard.list()) != 0 {
+		t.Error("a database error counted as a failed sign in")
+	}
+}
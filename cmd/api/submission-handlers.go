@@ -0,0 +1,252 @@
+package main
+
+import (
+	"backend/models"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const maxSubmissionSize = 1 << 20
+
+// submissions are limited per client address to a burst of
+// submissionBurst, then one every 6 minutes
+const (
+	submissionBurst      = 5
+	submissionsPerMinute = 1.0 / 6
+)
+
+// createSubmission stores a reader's article and course results for moderation.
+// It takes the editContent form plus repeated courseId, result and isDecision
+// fields. Requests filling the hidden website field are accepted and dropped.
+func (app *application) createSubmission(w http.ResponseWriter, r *http.Request) {
+	allowed, _, wait := app.submissionBuckets.take(app.clientIP(r), submissionsPerMinute, submissionBurst)
+	if !allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		app.errorJSON(w, errors.New("too many submissions, try again later"), http.StatusTooManyRequests)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmissionSize)
+
+	err := r.ParseMultipartForm(maxSubmissionSize)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid or too large submission"))
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	if r.FormValue("website") != "" {
+		app.writeJSON(w, http.StatusAccepted, ok, "response")
+		return
+	}
+
+	s, err := app.submissionFromForm(r)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	s.Content.ID = 0
+	s.Email = strings.TrimSpace(r.FormValue("email"))
+	s.RemoteAddr = app.clientIP(r)
+
+	_, err = app.db(r).InsertSubmission(*s)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusAccepted, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getSubmissions(w http.ResponseWriter, r *http.Request) {
+	submissions, err := app.db(r).GetSubmissions(r.URL.Query().Get("status"))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, submissions, "submissions")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	s, err := app.db(r).GetSubmission(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, s, "submission")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// editSubmission replaces the content, results and reviewer note of a pending
+// submission with the posted form. A content id may be set to choose the id
+// used on approval.
+func (app *application) editSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	r.ParseMultipartForm(0)
+
+	s, err := app.submissionFromForm(r)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	s.ID = id
+	s.ReviewerNote = r.FormValue("reviewerNote")
+
+	err = app.db(r).UpdateSubmission(*s)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	s, err = app.db(r).GetSubmission(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, s, "submission")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) approveSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	r.ParseMultipartForm(0)
+
+	contentID, err := app.db(r).ApproveSubmission(id, r.FormValue("reviewerNote"), userID(r))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, approvedResp{OK: true, ContentID: contentID}, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) rejectSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	r.ParseMultipartForm(0)
+
+	err = app.db(r).RejectSubmission(id, r.FormValue("reviewerNote"), userID(r))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// submissionFromForm reads and validates the content and course results of a submission form
+func (app *application) submissionFromForm(r *http.Request) (*models.Submission, error) {
+	var s models.Submission
+
+	s.Content = contentFromForm(r)
+	if strings.TrimSpace(s.Content.Title) == "" {
+		return nil, errors.New("title is required")
+	}
+	if strings.TrimSpace(s.Content.Link) == "" && strings.TrimSpace(s.Content.Content) == "" {
+		return nil, errors.New("a link or the article text is required")
+	}
+
+	courseIDs := r.Form["courseId"]
+	results := r.Form["result"]
+	decisions := r.Form["isDecision"]
+	if len(results) != len(courseIDs) || (len(decisions) > 0 && len(decisions) != len(courseIDs)) {
+		return nil, errors.New("every courseId needs a result")
+	}
+	if len(courseIDs) == 0 {
+		return &s, nil
+	}
+
+	courses, err := app.db(r).CourseIndex()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int]bool, len(courses))
+	for _, c := range courses {
+		known[c.ID] = true
+	}
+
+	seen := make(map[int]bool)
+	for i := range courseIDs {
+		var res models.SubmissionResult
+
+		res.CourseID, err = strconv.Atoi(courseIDs[i])
+		if err != nil || !known[res.CourseID] {
+			return nil, fmt.Errorf("unknown course %q", courseIDs[i])
+		}
+		if seen[res.CourseID] {
+			return nil, fmt.Errorf("course %d is listed twice", res.CourseID)
+		}
+		seen[res.CourseID] = true
+
+		res.Result = results[i]
+		if res.Result != "Admission" && res.Result != "Rejection" {
+			return nil, fmt.Errorf("result for course %d must be Admission or Rejection", res.CourseID)
+		}
+
+		if len(decisions) > 0 {
+			res.IsDecision, _ = strconv.ParseBool(decisions[i])
+		}
+
+		s.Results = append(s.Results, res)
+	}
+
+	return &s, nil
+}
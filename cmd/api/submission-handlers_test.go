@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubmissionRateLimit(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.routes()
+
+	// the honeypot field is accepted without touching the database
+	submit := func(addr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		mw.WriteField("website", "spam")
+		mw.WriteField("title", "t")
+		mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/submissions", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.RemoteAddr = addr + ":1234"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < submissionBurst; i++ {
+		if rec := submit("192.0.2.1"); rec.Code != http.StatusAccepted {
+			t.Fatalf("submission %d: got %d %s", i, rec.Code, rec.Body)
+		}
+	}
+
+	rec := submit("192.0.2.1")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("got %d with Retry-After %q, want 429 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	if rec := submit("192.0.2.2"); rec.Code != http.StatusAccepted {
+		t.Errorf("another address: got %d %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"backend/extract"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// suggestContent proposes author fields for the editContent form from the
+// article text, given as content or as the id of an ingested draft
+func (app *application) suggestContent(w http.ResponseWriter, r *http.Request) {
+	r.ParseMultipartForm(0)
+
+	text := r.FormValue("content")
+
+	if draftID := r.FormValue("draftId"); draftID != "" {
+		id, err := strconv.Atoi(draftID)
+		if err != nil {
+			app.errorJSON(w, errors.New("invalid draftId parameter"))
+			return
+		}
+
+		draft, err := app.db(r).GetDraft(id)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+		text = draft.Content
+	}
+
+	if text == "" {
+		app.errorJSON(w, errors.New("content or draftId is required"))
+		return
+	}
+
+	err := app.writeJSON(w, http.StatusOK, extract.Suggest(text), "suggestions")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// suggestArticleCourses ranks the courses an article mentions so they can be
+// linked with editArticle
+func (app *application) suggestArticleCourses(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	article, err := app.db(r).GetOneArticle(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	body, err := app.db(r).GetContentBody(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	courses, err := app.db(r).CourseNames()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	linked := make(map[int]bool)
+	for _, ac := range article.ArticleCourse {
+		linked[ac.Course.ID] = true
+	}
+
+	matches := extract.MatchCourses(article.Title+"\n"+body, courses)
+	for i := range matches {
+		matches[i].Linked = linked[matches[i].CourseID]
+	}
+
+	err = app.writeJSON(w, http.StatusOK, matches, "suggestions")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"backend/models"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pascaldekloe/jwt"
@@ -16,6 +23,27 @@ type Credentials struct {
 	Password string `json:"password"`
 }
 
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// errInvalidCredentials is the only error a failed sign in returns, so
+// responses do not reveal whether an account exists
+var errInvalidCredentials = errors.New("invalid credentials")
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// compareDummyHash spends the time of a password check for unknown accounts
+func compareDummyHash(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), 12)
+	})
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
 func (app *application) signIn(w http.ResponseWriter, r *http.Request) {
 	var creds Credentials
 
@@ -25,9 +53,24 @@ func (app *application) signIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := app.models.DB.GetUser(creds.Username)
+	ip := app.clientIP(r)
+	account := strings.ToLower(strings.TrimSpace(creds.Username))
+
+	if wait := app.signInGuard.blocked(ip, account); wait > 0 {
+		w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(wait.Seconds()))))
+		app.errorJSON(w, errors.New("too many failed sign ins, try again later"), http.StatusTooManyRequests)
+		return
+	}
+
+	user, err := app.db(r).GetUser(account)
+	if errors.Is(err, sql.ErrNoRows) {
+		compareDummyHash(creds.Password)
+		app.signInGuard.fail(ip, account)
+		app.errorJSON(w, errInvalidCredentials, http.StatusUnauthorized)
+		return
+	}
 	if err != nil {
-		app.errorJSON(w, errors.New("cannot find user"))
+		app.errorJSON(w, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -36,25 +79,155 @@ func (app *application) signIn(w http.ResponseWriter, r *http.Request) {
 
 	err = bcrypt.CompareHashAndPassword([]byte(hasedPassword), []byte(creds.Password))
 	if err != nil {
-		app.errorJSON(w, errors.New("unauthorized"))
+		app.signInGuard.fail(ip, account)
+		app.errorJSON(w, errInvalidCredentials, http.StatusUnauthorized)
+		return
+	}
+
+	app.signInGuard.succeed(account)
+
+	if user.EmailVerifiedAt == nil {
+		app.errorJSON(w, errors.New("email address is not verified"), http.StatusForbidden)
+		return
+	}
+
+	app.issueTokens(w, r, user, "")
+}
+
+// refreshToken exchanges the refresh token from the refresh_token cookie, or
+// from a json body {"refresh_token": "..."}, for a new access and refresh token
+func (app *application) refreshToken(w http.ResponseWriter, r *http.Request) {
+	token := requestRefreshToken(r)
+	if token == "" {
+		app.errorJSON(w, errors.New("missing refresh token"), http.StatusUnauthorized)
+		return
+	}
+
+	id, next, err := app.db(r).RotateRefreshToken(token, app.config.jwt.refreshTTL)
+	if errors.Is(err, models.ErrInvalidToken) || errors.Is(err, models.ErrRefreshTokenReused) {
+		app.setRefreshCookie(w, "", -1)
+		app.errorJSON(w, err, http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	user, err := app.db(r).GetUserByID(id)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.issueTokens(w, r, user, next)
+}
+
+// logout revokes the refresh token family of the session and, when a valid
+// bearer token is sent, denylists its id until it expires
+func (app *application) logout(w http.ResponseWriter, r *http.Request) {
+	if token := requestRefreshToken(r); token != "" {
+		err := app.db(r).RevokeRefreshFamily(token)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	}
+
+	if r.Header.Get("Authorization") != "" {
+		claims, _, err := app.verifyToken(r)
+		if err == nil {
+			err = app.db(r).RevokeJTI(claims.ID, claims.Expires.Time())
+			if err != nil {
+				app.errorJSON(w, err)
+				return
+			}
+		}
+	}
+
+	app.setRefreshCookie(w, "", -1)
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err := app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// issueTokens responds with a new access token and sets the refresh token
+// cookie, starting a new refresh token family when refresh is empty
+func (app *application) issueTokens(w http.ResponseWriter, r *http.Request, user *models.User, refresh string) {
+	if refresh == "" {
+		var err error
+		refresh, err = app.db(r).IssueRefreshToken(user.ID, app.config.jwt.refreshTTL)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	}
+
+	jti := make([]byte, 16)
+	_, err := rand.Read(jti)
+	if err != nil {
+		app.errorJSON(w, err)
 		return
 	}
 
 	var claims jwt.Claims
 	// claims.Subject = fmt.Sprint(validUser.ID)
 	claims.Subject = fmt.Sprint(user.ID)
+	claims.ID = hex.EncodeToString(jti)
 	claims.Issued = jwt.NewNumericTime(time.Now())
 	claims.NotBefore = jwt.NewNumericTime(time.Now())
-	claims.Expires = jwt.NewNumericTime(time.Now().Add(24 * time.Hour))
+	claims.Expires = jwt.NewNumericTime(time.Now().Add(app.config.jwt.accessTTL))
 	claims.Issuer = "https://noworneverev.github.io/go-germany/"
 	claims.Audiences = []string{"https://noworneverev.github.io/go-germany/"}
+	claims.Set = map[string]interface{}{"roles": user.Roles}
 
-	jwtBytes, err := claims.HMACSign(jwt.HS256, []byte(app.config.jwt.secret))
+	jwtBytes, err := app.keys.sign(&claims)
 	if err != nil {
 		app.errorJSON(w, errors.New("error signing"))
 		return
 	}
 
+	app.setRefreshCookie(w, refresh, int(app.config.jwt.refreshTTL/time.Second))
+
 	app.writeJSON(w, http.StatusOK, string(jwtBytes), "response")
+}
+
+const refreshCookie = "refresh_token"
+
+// setRefreshCookie sets the HttpOnly refresh token cookie, a negative maxAge deletes it
+func (app *application) setRefreshCookie(w http.ResponseWriter, token string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     refreshCookie,
+		Value:    token,
+		Path:     "/v1/account",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	// the production frontend is served from another site
+	if app.config.env == "production" {
+		cookie.Secure = true
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+
+	http.SetCookie(w, cookie)
+}
+
+func requestRefreshToken(r *http.Request) string {
+	if c, err := r.Cookie(refreshCookie); err == nil && c.Value != "" {
+		return c.Value
+	}
+
+	var body refreshRequest
+	json.NewDecoder(r.Body).Decode(&body)
 
+	return body.RefreshToken
 }
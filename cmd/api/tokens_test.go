@@ -0,0 +1,144 @@
+package main
+
+import (
+	"backend/mailer"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// session sends requests through h with the refresh token cookie and the
+// bearer token of the last sign in or refresh
+type session struct {
+	t       *testing.T
+	h       http.Handler
+	access  string
+	refresh string
+}
+
+func (s *session) do(method, target string, body any) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var b []byte
+	if body != nil {
+		var err error
+		b, err = json.Marshal(body)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+	}
+
+	r := httptest.NewRequest(method, target, bytes.NewReader(b))
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if s.refresh != "" {
+		r.AddCookie(&http.Cookie{Name: refreshCookie, Value: s.refresh})
+	}
+	if s.access != "" {
+		r.Header.Set("Authorization", "Bearer "+s.access)
+	}
+
+	rec := httptest.NewRecorder()
+	s.h.ServeHTTP(rec, r)
+	return rec
+}
+
+// keep stores the tokens of a successful sign in or refresh
+func (s *session) keep(rec *httptest.ResponseRecorder) {
+	s.t.Helper()
+
+	var body struct {
+		Response string `json:"response"`
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &body)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	s.access = body.Response
+
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == refreshCookie {
+			s.refresh = c.Value
+		}
+	}
+}
+
+func TestRefreshAndLogout(t *testing.T) {
+	app := newTestApplication(t)
+	withTestDB(t, app)
+	mails := app.mailer.(*mailer.Memory)
+	h := app.routes()
+
+	email := fmt.Sprintf("test-%d@example.com", time.Now().UnixNano())
+	password := "first password 1"
+	t.Cleanup(func() {
+		app.models.DB.DB.Exec(`delete from gogermany_user where email = $1`, email)
+	})
+
+	rec := do(t, h, http.MethodPost, "/v1/account/register", accountRequest{Email: email, Password: password})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("register: got %d %s", rec.Code, rec.Body)
+	}
+	app.mails.Wait()
+	rec = do(t, h, http.MethodPost, "/v1/account/verify", accountRequest{Token: mailedToken(t, mails, email)})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("verify: got %d %s", rec.Code, rec.Body)
+	}
+
+	signIn := func() *session {
+		s := &session{t: t, h: h}
+		rec := s.do(http.MethodPost, "/v1/account/signin", Credentials{Username: email, Password: password})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("sign in: got %d %s", rec.Code, rec.Body)
+		}
+		s.keep(rec)
+		return s
+	}
+
+	s := signIn()
+	first := s.refresh
+	rec = s.do(http.MethodPost, "/v1/account/refresh", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("refresh: got %d %s", rec.Code, rec.Body)
+	}
+	s.keep(rec)
+	if s.refresh == first {
+		t.Fatal("refresh did not rotate the refresh token")
+	}
+
+	// a replayed refresh token ends the whole session
+	stolen := &session{t: t, h: h, refresh: first}
+	rec = stolen.do(http.MethodPost, "/v1/account/refresh", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("reused refresh token: got %d %s, want 401", rec.Code, rec.Body)
+	}
+	rec = s.do(http.MethodPost, "/v1/account/refresh", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after a reuse: got %d %s, want the family revoked", rec.Code, rec.Body)
+	}
+
+	s = signIn()
+	rec = s.do(http.MethodGet, "/v1/account", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("account: got %d %s", rec.Code, rec.Body)
+	}
+
+	rec = s.do(http.MethodPost, "/v1/account/logout", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("logout: got %d %s", rec.Code, rec.Body)
+	}
+
+	rec = s.do(http.MethodGet, "/v1/account", nil)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("account after logout: got %d %s, want the access token rejected", rec.Code, rec.Body)
+	}
+	rec = s.do(http.MethodPost, "/v1/account/refresh", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: got %d %s, want 401", rec.Code, rec.Body)
+	}
+}
@@ -24,7 +24,7 @@ func (app *application) editUniversity(w http.ResponseWriter, r *http.Request) {
 	university.Link = r.FormValue("link")
 	university.QsRanking, _ = strconv.Atoi(r.FormValue("qsRanking"))
 
-	err := app.models.DB.InsertUniversity(university)
+	err := app.db(r).InsertUniversity(university)
 	if err != nil {
 		app.errorJSON(w, err)
 		return
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// accountResp is the signed in user as returned by getAccount
+type accountResp struct {
+	ID          int      `json:"id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+type rolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// getAccount returns the signed in user with the permissions of their roles
+func (app *application) getAccount(w http.ResponseWriter, r *http.Request) {
+	user, err := app.db(r).GetUserByID(userID(r))
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, accountResp{
+		ID:          user.ID,
+		Email:       user.Email,
+		Roles:       user.Roles,
+		Permissions: permissionsOf(user.Roles),
+	}, "account")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+func (app *application) getUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := app.db(r).GetUsers()
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, users, "users")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
+
+// setUserRoles replaces the roles of a user, taking a json body {"roles": [...]}.
+// Changes apply once the user's access token is refreshed, which reloads the roles.
+func (app *application) setUserRoles(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid id parameter"))
+		return
+	}
+
+	var req rolesRequest
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid request body"))
+		return
+	}
+
+	seen := make(map[string]bool)
+	roles := []string{}
+	for _, role := range req.Roles {
+		if !validRole(role) {
+			app.errorJSON(w, fmt.Errorf("unknown role %q", role))
+			return
+		}
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+
+	if id == userID(r) && !hasPermission(roles, permManageUsers) {
+		app.errorJSON(w, errors.New("cannot remove your own permission to manage users"))
+		return
+	}
+
+	err = app.db(r).SetUserRoles(id, roles)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	ok := jsonResp{
+		OK: true,
+	}
+
+	err = app.writeJSON(w, http.StatusOK, ok, "response")
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"backend/models"
 	"encoding/json"
 	"net/http"
 )
@@ -16,6 +17,9 @@ func (app *application) writeJSON(w http.ResponseWriter, status int, data interf
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if status == http.StatusOK {
+		w.Header().Set("ETag", etag(js))
+	}
 	w.WriteHeader(status)
 	w.Write(js)
 
@@ -35,5 +39,12 @@ func (app *application) errorJSON(w http.ResponseWriter, err error, status ...in
 		Message: err.Error(),
 	}
 
+	recordError(w, err)
+
 	app.writeJSON(w, statusCode, theError, "error")
 }
+
+// db returns the models for the queries of r, which are traced as part of the request
+func (app *application) db(r *http.Request) *models.DBModel {
+	return app.models.DB.WithContext(r.Context())
+}
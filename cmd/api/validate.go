@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxJSONBody limits the json and form request bodies read for validation
+const maxJSONBody = 1 << 20
+
+// apiRouter registers routes on an httprouter.Router, checking that each one
+// is documented in apiRoutes and validating its requests against the entry
+type apiRouter struct {
+	*httprouter.Router
+	app          *application
+	documented   map[string]*apiRoute
+	registered   map[string]bool
+	undocumented []string
+}
+
+func (app *application) newRouter() *apiRouter {
+	rt := &apiRouter{
+		Router:     httprouter.New(),
+		app:        app,
+		documented: make(map[string]*apiRoute, len(apiRoutes)),
+		registered: make(map[string]bool),
+	}
+
+	for i := range apiRoutes {
+		route := &apiRoutes[i]
+		rt.documented[route.Method+" "+route.Path] = route
+	}
+
+	return rt
+}
+
+func (rt *apiRouter) GET(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodGet, path, handle)
+}
+
+func (rt *apiRouter) POST(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodPost, path, handle)
+}
+
+func (rt *apiRouter) Handle(method, path string, handle httprouter.Handle) {
+	key := method + " " + path
+	rt.registered[key] = true
+
+	route, ok := rt.documented[key]
+	if !ok {
+		rt.undocumented = append(rt.undocumented, key)
+		rt.Router.Handle(method, path, handle)
+		return
+	}
+
+	rt.Router.Handle(method, path, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		setRoute(r, path)
+
+		if route.Auth {
+			// left to validated, which runs once the caller is known to have access
+			r = r.WithContext(context.WithValue(r.Context(), validationKey, &pendingValidation{route: route, ps: ps}))
+			handle(w, r, ps)
+			return
+		}
+
+		err := validateRequest(route, w, r, ps)
+		if err != nil {
+			rt.app.errorJSON(w, err)
+			return
+		}
+		handle(w, r, ps)
+	})
+}
+
+const validationKey contextKey = "validation"
+
+// pendingValidation is the check of a request to a route needing a token
+type pendingValidation struct {
+	route *apiRoute
+	ps    httprouter.Params
+}
+
+// validated validates requests to routes needing a token. It goes after
+// checkToken and requirePermission, so callers without access get a 401 or
+// 403 without the body being read and learn nothing about its fields.
+func (app *application) validated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v, ok := r.Context().Value(validationKey).(*pendingValidation); ok {
+			err := validateRequest(v.route, w, r, v.ps)
+			if err != nil {
+				app.errorJSON(w, err)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Handler puts the params in the request context like httprouter.Router.Handler
+func (rt *apiRouter) Handler(method, path string, handler http.Handler) {
+	rt.Handle(method, path, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if len(ps) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), httprouter.ParamsKey, ps))
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (rt *apiRouter) HandlerFunc(method, path string, handler http.HandlerFunc) {
+	rt.Handler(method, path, handler)
+}
+
+// check reports registered routes missing from apiRoutes and documented
+// routes that were never registered
+func (rt *apiRouter) check() error {
+	var problems []string
+	for _, key := range rt.undocumented {
+		problems = append(problems, "undocumented route "+key)
+	}
+
+	var stale []string
+	for key := range rt.documented {
+		if !rt.registered[key] {
+			stale = append(stale, "documented route "+key+" is not registered")
+		}
+	}
+	sort.Strings(stale)
+	problems = append(problems, stale...)
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateRequest checks the path and query parameters of a request, its json
+// body and its form fields against the route's entry. Forms uploading files
+// are left to the handlers, which bound their size.
+func validateRequest(route *apiRoute, w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	for _, p := range ps {
+		param, ok := pathParams[p.Key]
+		if !ok {
+			continue
+		}
+		err := checkParam(param, p.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := r.URL.Query()
+	for _, param := range route.Query {
+		value := query.Get(param.Name)
+		if value == "" {
+			if param.Required {
+				return fmt.Errorf("%s is required", param.Name)
+			}
+			continue
+		}
+		err := checkParam(param, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if route.Body != nil {
+		return validateBody(route, r)
+	}
+
+	if len(route.Form) > 0 && !hasFile(route.Form) {
+		return validateForm(route, w, r)
+	}
+
+	return nil
+}
+
+func hasFile(form []apiParam) bool {
+	for _, f := range form {
+		if f.Type == "file" {
+			return true
+		}
+	}
+	return false
+}
+
+// validateForm checks the documented fields of url encoded and multipart
+// bodies. The parsed form stays on the request for the handler.
+func validateForm(route *apiRoute, w http.ResponseWriter, r *http.Request) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxJSONBody)
+		err := r.ParseMultipartForm(maxJSONBody)
+		if err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return errors.New("invalid or too large form")
+		}
+	}
+
+	for _, param := range route.Form {
+		values := r.PostForm[param.Name]
+		if len(values) == 0 {
+			if param.Required {
+				return fmt.Errorf("%s is required", param.Name)
+			}
+			continue
+		}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			err := checkParam(param, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkParam(param apiParam, value string) error {
+	switch param.Type {
+	case "integer":
+		_, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be an integer", param.Name)
+		}
+	case "boolean":
+		_, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", param.Name)
+		}
+	}
+
+	if len(param.Enum) > 0 {
+		for _, e := range param.Enum {
+			if strings.EqualFold(e, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s", param.Name, strings.Join(param.Enum, ", "))
+	}
+
+	return nil
+}
+
+// validateBody checks that the body is a json object with the required
+// fields and that known fields have the documented type. An empty body is
+// fine when no field is required. The body is put back for the handler.
+func validateBody(route *apiRoute, r *http.Request) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxJSONBody+1))
+	if err != nil {
+		return errors.New("invalid request body")
+	}
+	if len(body) > maxJSONBody {
+		return errors.New("request body too large")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 && len(route.BodyRequired) == 0 {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return errors.New("request body must be a json object")
+	}
+
+	for _, name := range route.BodyRequired {
+		if v, ok := fields[name]; !ok || string(v) == "null" {
+			return fmt.Errorf("%s is required", name)
+		}
+	}
+
+	t := reflect.TypeOf(route.Body)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		v, ok := fields[name]
+		if !ok || string(v) == "null" {
+			continue
+		}
+		if !jsonKindOf(f.Type.Kind(), v) {
+			return fmt.Errorf("%s has the wrong type", name)
+		}
+	}
+
+	return nil
+}
+
+// jsonKindOf reports whether the raw json value can be decoded into kind
+func jsonKindOf(kind reflect.Kind, v json.RawMessage) bool {
+	switch kind {
+	case reflect.String:
+		return v[0] == '"'
+	case reflect.Bool:
+		return v[0] == 't' || v[0] == 'f'
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return v[0] == '-' || (v[0] >= '0' && v[0] <= '9')
+	case reflect.Slice:
+		return v[0] == '['
+	default:
+		return true
+	}
+}
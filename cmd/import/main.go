@@ -0,0 +1,72 @@
+// Command import loads a csv file of universities or courses, e.g.
+//
+//	go run ./cmd/import -kind courses -file courses.csv -dry-run
+package main
+
+import (
+	"backend/importer"
+	"backend/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	godotenv.Load(".env")
+
+	var dsn, kind, file string
+	var dryRun bool
+
+	flag.StringVar(&dsn, "dsn", os.Getenv("DATABASE_URL"), "Postgres connection string")
+	flag.StringVar(&kind, "kind", "", "What the file contains (universities|courses)")
+	flag.StringVar(&file, "file", "", "Path of the csv file")
+	flag.BoolVar(&dryRun, "dry-run", false, "Validate and report without writing")
+	flag.Parse()
+
+	if kind == "" || file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m := models.NewModels(db)
+
+	report, err := importer.Run(&m.DB, kind, f, dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "\t")
+	enc.Encode(report)
+
+	if report.Invalid > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,135 @@
+package daad
+
+import (
+	"backend/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Compare computes what applying the snapshot would change. Programmes are
+// matched to courses by the id in Daadlink and to universities by name. New
+// programmes without a known university or course type are unmatched.
+func Compare(programmes []models.DaadProgramme, courses []models.Course, universities []models.University) models.DaadDiff {
+	diff := models.DaadDiff{
+		New:       []models.DaadProgramme{},
+		Changed:   []models.DaadChangedCourse{},
+		Vanished:  []models.DaadVanishedCourse{},
+		Unmatched: []models.DaadProgramme{},
+	}
+
+	byDaadID := make(map[int]models.Course)
+	for _, c := range courses {
+		if id := DaadID(c.Daadlink); id != 0 {
+			byDaadID[id] = c
+		}
+	}
+
+	inSnapshot := make(map[int]bool)
+	for _, p := range programmes {
+		inSnapshot[p.DaadID] = true
+
+		c, ok := byDaadID[p.DaadID]
+		if !ok {
+			p.UniversityID = matchUniversity(p, universities)
+			ct, ok := courseType(p.CourseType)
+			if ok {
+				p.CourseType = ct
+			}
+			if p.UniversityID == 0 || !ok {
+				diff.Unmatched = append(diff.Unmatched, p)
+			} else {
+				diff.New = append(diff.New, p)
+			}
+			continue
+		}
+
+		changes := fieldChanges(c, p)
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, models.DaadChangedCourse{
+				DaadID:   p.DaadID,
+				CourseID: c.ID,
+				NameEn:   c.NameEn,
+				Changes:  changes,
+			})
+		}
+	}
+
+	for id, c := range byDaadID {
+		if c.IsFromDaad && !inSnapshot[id] {
+			diff.Vanished = append(diff.Vanished, models.DaadVanishedCourse{DaadID: id, CourseID: c.ID, NameEn: c.NameEn})
+		}
+	}
+	sort.Slice(diff.Vanished, func(i, j int) bool { return diff.Vanished[i].DaadID < diff.Vanished[j].DaadID })
+
+	return diff
+}
+
+// courseType maps the degree of a programme, as the code of the search API
+// or as named on detail pages, to the course_type of the course table
+func courseType(degree string) (string, bool) {
+	degree = strings.ToLower(degree)
+	switch {
+	case degree == "1" || strings.HasPrefix(degree, "bachelor"):
+		return "1", true
+	case degree == "2" || strings.HasPrefix(degree, "master"):
+		return "2", true
+	case degree == "3" || strings.HasPrefix(degree, "phd") || strings.HasPrefix(degree, "doctor"):
+		return "3", true
+	}
+	return "", false
+}
+
+func fieldChanges(c models.Course, p models.DaadProgramme) []models.DaadFieldChange {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"name_en", c.NameEn, p.NameEn},
+		{"name_en_short", c.NameEnShort, p.NameEnShort},
+		{"subject", c.Subject, p.Subject},
+		{"tuition_fees", c.TuitionFees, p.TuitionFees},
+		{"beginning", c.Beginning, p.Beginning},
+		{"programme_duration", c.ProgrammeDuration, p.ProgrammeDuration},
+		{"application_deadline", c.ApplicationDeadline, p.ApplicationDeadline},
+		{"daadlink", c.Daadlink, p.Daadlink},
+	}
+
+	var changes []models.DaadFieldChange
+	for _, f := range fields {
+		// snapshots often leave fields out, that is not a change
+		if f.new == "" || clean(f.old) == f.new {
+			continue
+		}
+		changes = append(changes, models.DaadFieldChange{Field: f.name, Old: f.old, New: f.new})
+	}
+
+	// booleans are only known for snapshots from the search API
+	if p.IsElearning != nil && c.IsElearning != *p.IsElearning {
+		changes = append(changes, models.DaadFieldChange{
+			Field: "is_elearning", Old: strconv.FormatBool(c.IsElearning), New: strconv.FormatBool(*p.IsElearning),
+		})
+	}
+	if p.IsCompleteOnlinePossible != nil && c.IsCompleteOnlinePossible != *p.IsCompleteOnlinePossible {
+		changes = append(changes, models.DaadFieldChange{
+			Field: "is_complete_online_possible", Old: strconv.FormatBool(c.IsCompleteOnlinePossible), New: strconv.FormatBool(*p.IsCompleteOnlinePossible),
+		})
+	}
+
+	return changes
+}
+
+func matchUniversity(p models.DaadProgramme, universities []models.University) int {
+	name := strings.ToLower(p.University)
+	if name == "" {
+		return 0
+	}
+
+	for _, u := range universities {
+		if strings.ToLower(u.NameEn) == name || (u.NameCh != "" && u.NameCh == p.University) {
+			return u.ID
+		}
+	}
+
+	return 0
+}
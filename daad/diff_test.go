@@ -0,0 +1,36 @@
+package daad
+
+import (
+	"backend/models"
+	"testing"
+)
+
+func TestCompareCourseTypes(t *testing.T) {
+	universities := []models.University{{ID: 7, NameEn: "Technical University of Munich"}}
+	programmes := []models.DaadProgramme{
+		{DaadID: 1, University: "Technical University of Munich", CourseType: "2"},
+		{DaadID: 2, University: "Technical University of Munich", CourseType: "Master of Science"},
+		{DaadID: 3, University: "Technical University of Munich", CourseType: "Language course"},
+		{DaadID: 4, University: "Technical University of Munich", CourseType: ""},
+		{DaadID: 5, University: "Unknown University", CourseType: "1"},
+	}
+
+	diff := Compare(programmes, nil, universities)
+
+	if len(diff.New) != 2 {
+		t.Fatalf("got %d new programmes, want 2", len(diff.New))
+	}
+	for _, p := range diff.New {
+		if p.CourseType != "2" || p.UniversityID != 7 {
+			t.Errorf("programme %d: got course type %q university %d, want 2 and 7", p.DaadID, p.CourseType, p.UniversityID)
+		}
+	}
+
+	var unmatched []int
+	for _, p := range diff.Unmatched {
+		unmatched = append(unmatched, p.DaadID)
+	}
+	if len(unmatched) != 3 || unmatched[0] != 3 || unmatched[1] != 4 || unmatched[2] != 5 {
+		t.Errorf("got unmatched %v, want [3 4 5]", unmatched)
+	}
+}
@@ -0,0 +1,278 @@
+// Package daad reads snapshots of the DAAD International Programmes database
+// and compares them with the course table.
+package daad
+
+import (
+	"backend/models"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const daadHost = "https://www2.daad.de"
+
+var detailID = regexp.MustCompile(`(?:/detail/|[?&]id=)(\d+)`)
+
+// DaadID extracts the programme id from a DAAD detail link, 0 if there is none
+func DaadID(link string) int {
+	m := detailID.FindStringSubmatch(link)
+	if m == nil {
+		return 0
+	}
+	id, _ := strconv.Atoi(m[1])
+	return id
+}
+
+// LoadSnapshot reads every .json and .html file below dir. JSON files hold the
+// search API response ({"courses": [...]}) or a plain array of courses, HTML
+// files are saved programme detail pages.
+func LoadSnapshot(dir string) ([]models.DaadProgramme, error) {
+	var programmes []models.DaadProgramme
+	seen := make(map[int]bool)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		var found []models.DaadProgramme
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			found, err = readJSON(path)
+		case ".html", ".htm":
+			var p *models.DaadProgramme
+			p, err = readHTML(path)
+			if p != nil {
+				found = append(found, *p)
+			}
+		default:
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, p := range found {
+			if p.DaadID == 0 || seen[p.DaadID] {
+				continue
+			}
+			seen[p.DaadID] = true
+			programmes = append(programmes, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(programmes, func(i, j int) bool { return programmes[i].DaadID < programmes[j].DaadID })
+
+	return programmes, nil
+}
+
+// flexString accepts json strings and numbers
+type flexString string
+
+func (f *flexString) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		*f = flexString(s)
+		return nil
+	}
+	*f = flexString(strings.TrimSpace(string(b)))
+	return nil
+}
+
+type jsonCourse struct {
+	ID                       flexString `json:"id"`
+	CourseName               string     `json:"courseName"`
+	CourseNameShort          string     `json:"courseNameShort"`
+	Academy                  string     `json:"academy"`
+	City                     string     `json:"city"`
+	CourseType               flexString `json:"courseType"`
+	Subject                  string     `json:"subject"`
+	Languages                []string   `json:"languages"`
+	TuitionFees              string     `json:"tuitionFees"`
+	Beginning                string     `json:"beginning"`
+	ProgrammeDuration        string     `json:"programmeDuration"`
+	ApplicationDeadline      string     `json:"applicationDeadline"`
+	IsElearning              *bool      `json:"isElearning"`
+	IsCompleteOnlinePossible *bool      `json:"isCompleteOnlinePossible"`
+	Link                     string     `json:"link"`
+}
+
+func readJSON(path string) ([]models.DaadProgramme, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var courses []jsonCourse
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		err = json.Unmarshal(b, &courses)
+	} else {
+		var response struct {
+			Courses []jsonCourse `json:"courses"`
+		}
+		err = json.Unmarshal(b, &response)
+		courses = response.Courses
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var programmes []models.DaadProgramme
+	for _, c := range courses {
+		p := models.DaadProgramme{
+			University:               clean(c.Academy),
+			City:                     clean(c.City),
+			CourseType:               clean(string(c.CourseType)),
+			NameEn:                   clean(c.CourseName),
+			NameEnShort:              clean(c.CourseNameShort),
+			Subject:                  clean(c.Subject),
+			Languages:                c.Languages,
+			TuitionFees:              clean(c.TuitionFees),
+			Beginning:                clean(c.Beginning),
+			ProgrammeDuration:        clean(c.ProgrammeDuration),
+			ApplicationDeadline:      clean(c.ApplicationDeadline),
+			IsElearning:              c.IsElearning,
+			IsCompleteOnlinePossible: c.IsCompleteOnlinePossible,
+			Daadlink:                 absolute(c.Link),
+		}
+		p.DaadID, _ = strconv.Atoi(string(c.ID))
+		if p.DaadID == 0 {
+			p.DaadID = DaadID(p.Daadlink)
+		}
+		programmes = append(programmes, p)
+	}
+
+	return programmes, nil
+}
+
+// readHTML reads a saved detail page. The canonical link carries the id and
+// the facts are taken from the page's definition lists.
+func readHTML(path string) (*models.DaadProgramme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := html.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var p models.DaadProgramme
+	var term string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "link":
+				if attr(n, "rel") == "canonical" {
+					p.Daadlink = absolute(attr(n, "href"))
+				}
+			case "meta":
+				if attr(n, "property") == "og:title" && p.NameEn == "" {
+					p.NameEn = clean(attr(n, "content"))
+				}
+			case "h1":
+				p.NameEn = clean(text(n))
+			case "dt":
+				term = strings.ToLower(clean(text(n)))
+			case "dd":
+				setField(&p, term, clean(text(n)))
+				term = ""
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	p.DaadID = DaadID(p.Daadlink)
+	if p.DaadID == 0 {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+func setField(p *models.DaadProgramme, term, value string) {
+	switch {
+	case term == "":
+	case strings.Contains(term, "university") || strings.Contains(term, "institution"):
+		p.University = value
+	case term == "city" || term == "location":
+		p.City = value
+	case term == "degree" || strings.Contains(term, "course type"):
+		p.CourseType = value
+	case strings.Contains(term, "language"):
+		for _, l := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '/' }) {
+			p.Languages = append(p.Languages, clean(l))
+		}
+	case strings.Contains(term, "tuition"):
+		p.TuitionFees = value
+	case strings.Contains(term, "beginning") || strings.Contains(term, "start"):
+		p.Beginning = value
+	case strings.Contains(term, "duration"):
+		p.ProgrammeDuration = value
+	case strings.Contains(term, "deadline"):
+		p.ApplicationDeadline = value
+	case strings.Contains(term, "subject") || strings.Contains(term, "field of study"):
+		p.Subject = value
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func text(n *html.Node) string {
+	var buf strings.Builder
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			buf.WriteString(n.Data)
+			buf.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+	return buf.String()
+}
+
+// clean collapses whitespace the way values are stored in the course table
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func absolute(link string) string {
+	link = strings.TrimSpace(link)
+	if strings.HasPrefix(link, "/") {
+		return daadHost + link
+	}
+	return link
+}
@@ -0,0 +1,199 @@
+package extract
+
+import (
+	"backend/models"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// CourseMatch is a course mentioned in an article. CourseID, Result and
+// IsDecision are what the editArticle form needs to link it.
+type CourseMatch struct {
+	CourseID   int       `json:"course_id"`
+	NameEn     string    `json:"name_en"`
+	University string    `json:"university"`
+	Score      float64   `json:"score"`
+	Result     string    `json:"result"`
+	IsDecision bool      `json:"is_decision"`
+	Linked     bool      `json:"linked"`
+	Mentions   []Mention `json:"mentions"`
+}
+
+// Mention is a matched course name, Start and End are rune offsets
+type Mention struct {
+	Match string `json:"match"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// maxCourseMatches bounds the result, generic names match many courses
+const maxCourseMatches = 20
+
+var (
+	admissionWords = []string{"admission", "admitted", "accepted", "acceptance", "zulassung", "zusage", "錄取", "上了", "拿到", "錄了", "admit"}
+	rejectionWords = []string{"rejection", "rejected", "ablehnung", "absage", "被拒", "拒絕", "沒上", "槓", "落榜", "reject"}
+	decisionWords  = []string{"decided", "decision", "will attend", "enrolled", "決定", "最後選擇", "最終選擇", "就讀", "入學", "去了"}
+)
+
+// MatchCourses ranks the courses whose names appear in text. A mention of the
+// course's university near the name raises the score.
+func MatchCourses(text string, courses []models.Course) []CourseMatch {
+	lower := toLower(text)
+
+	var matches []CourseMatch
+	for _, c := range courses {
+		names := []struct {
+			name   string
+			weight float64
+		}{
+			{c.NameEn, 0.7},
+			{c.NameCh, 0.6},
+			{c.NameEnShort, 0.4},
+			{c.NameChShort, 0.4},
+		}
+
+		var m CourseMatch
+		var spans [][2]int
+		for _, n := range names {
+			name := toLower(strings.TrimSpace(n.name))
+			if utf8.RuneCountInString(name) < 4 && !isHan(name) || name == "" {
+				continue
+			}
+			for _, s := range findAll(lower, name) {
+				spans = append(spans, s)
+				if n.weight > m.Score {
+					m.Score = n.weight
+				}
+			}
+		}
+		if len(spans) == 0 {
+			continue
+		}
+
+		uni := universitySpans(lower, c)
+		if len(uni) > 0 {
+			m.Score += 0.1
+			if near(spans, uni, 200) {
+				m.Score += 0.2
+			}
+		}
+
+		m.CourseID = c.ID
+		m.NameEn = c.NameEn
+		m.University = c.UniversityNameEn
+		m.Result, m.IsDecision = outcome(lower, spans)
+		for _, s := range spans {
+			m.Mentions = append(m.Mentions, Mention{
+				Match: text[s[0]:s[1]],
+				Start: utf8.RuneCountInString(text[:s[0]]),
+				End:   utf8.RuneCountInString(text[:s[1]]),
+			})
+		}
+		sort.Slice(m.Mentions, func(i, j int) bool { return m.Mentions[i].Start < m.Mentions[j].Start })
+
+		matches = append(matches, m)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return len(matches[i].Mentions) > len(matches[j].Mentions)
+	})
+	if len(matches) > maxCourseMatches {
+		matches = matches[:maxCourseMatches]
+	}
+
+	return matches
+}
+
+func universitySpans(lower string, c models.Course) [][2]int {
+	names := []string{c.UniversityNameEn, c.UniversityNameCh}
+	for _, s := range Schools {
+		if strings.EqualFold(s.Name, c.UniversityNameEn) || strings.EqualFold(s.Short, c.UniversityNameEn) {
+			names = append(names, s.Aliases...)
+		}
+	}
+
+	var spans [][2]int
+	for _, n := range names {
+		n = toLower(strings.TrimSpace(n))
+		if n != "" {
+			spans = append(spans, findAll(lower, n)...)
+		}
+	}
+	return spans
+}
+
+// outcome looks for admission, rejection and decision wording close to the mentions
+func outcome(lower string, spans [][2]int) (result string, decision bool) {
+	best := -1
+	for _, s := range spans {
+		from, to := max(0, s[0]-150), min(len(lower), s[1]+150)
+		window := lower[from:to]
+
+		for _, set := range []struct {
+			words  []string
+			result string
+		}{{admissionWords, "Admission"}, {rejectionWords, "Rejection"}} {
+			for _, w := range set.words {
+				for _, ws := range findAll(window, w) {
+					d := distance(s, [2]int{from + ws[0], from + ws[1]})
+					if best < 0 || d < best {
+						best, result = d, set.result
+					}
+				}
+			}
+		}
+
+		for _, w := range decisionWords {
+			if len(findAll(window, w)) > 0 {
+				decision = true
+			}
+		}
+	}
+
+	return result, decision && result == "Admission"
+}
+
+func findAll(s, w string) [][2]int {
+	var spans [][2]int
+	for from := 0; ; {
+		i := strings.Index(s[from:], w)
+		if i < 0 {
+			return spans
+		}
+		start, end := from+i, from+i+len(w)
+		if isWord(s, start, end) {
+			spans = append(spans, [2]int{start, end})
+		}
+		from = end
+	}
+}
+
+func near(a, b [][2]int, within int) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if distance(x, y) <= within {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func distance(a, b [2]int) int {
+	if a[1] <= b[0] {
+		return b[0] - a[1]
+	}
+	if b[1] <= a[0] {
+		return a[0] - b[1]
+	}
+	return 0
+}
+
+func isHan(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r > 0x2E80
+}
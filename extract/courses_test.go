@@ -0,0 +1,61 @@
+package extract
+
+import (
+	"backend/models"
+	"testing"
+)
+
+var testCourses = []models.Course{
+	{ID: 1, NameEn: "Informatik", NameCh: "資訊工程", UniversityNameEn: "Technical University of Munich"},
+	{ID: 2, NameEn: "Data Engineering and Analytics", UniversityNameEn: "Technical University of Munich"},
+	{ID: 3, NameEn: "Computational Science", NameCh: "計算科學"},
+}
+
+func TestMatchCourses(t *testing.T) {
+	tests := []struct {
+		name, text string
+		id         int
+		match      string
+		start      int
+		result     string
+	}{
+		{"ascii", "Got the admission for Informatik at Technical University of Munich", 1, "Informatik", 22, "Admission"},
+		{"case", "rejected by DATA ENGINEERING AND ANALYTICS", 2, "DATA ENGINEERING AND ANALYTICS", 12, "Rejection"},
+		{"han", "錄取 計算科學", 3, "計算科學", 3, "Admission"},
+		// Ⱥ is two bytes long, its lower case three
+		{"longer lower case", "ȺȺȺȺ Informatik", 1, "Informatik", 5, ""},
+		// the Kelvin sign is three bytes long, its lower case one
+		{"shorter lower case", "KKKK Informatik", 1, "Informatik", 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := MatchCourses(tt.text, testCourses)
+			if len(matches) == 0 || matches[0].CourseID != tt.id {
+				t.Fatalf("got %+v, want course %d first", matches, tt.id)
+			}
+
+			m := matches[0]
+			if m.Result != tt.result {
+				t.Errorf("got result %q, want %q", m.Result, tt.result)
+			}
+			if len(m.Mentions) != 1 {
+				t.Fatalf("got mentions %+v, want one", m.Mentions)
+			}
+			mention := m.Mentions[0]
+			if mention.Match != tt.match || mention.Start != tt.start {
+				t.Errorf("got mention %+v, want %q at %d", mention, tt.match, tt.start)
+			}
+			if got := string([]rune(tt.text)[mention.Start:mention.End]); got != tt.match {
+				t.Errorf("runes %d to %d are %q, want %q", mention.Start, mention.End, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestMatchCoursesWithoutUniversity(t *testing.T) {
+	matches := MatchCourses("Computational Science", testCourses)
+	if len(matches) != 1 || matches[0].University != "" {
+		t.Errorf("got %+v, want course 3 without university", matches)
+	}
+}
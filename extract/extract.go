@@ -0,0 +1,328 @@
+// Package extract proposes content fields from the text of an article:
+// applicant schools, departments, grades and language certificates.
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Suggestion is a proposed value for one field of the admin content form.
+// Start and End are rune offsets of Match in the scanned text.
+type Suggestion struct {
+	Field      string  `json:"field"`
+	Value      string  `json:"value"`
+	Confidence float64 `json:"confidence"`
+	Match      string  `json:"match"`
+	Start      int     `json:"start"`
+	End        int     `json:"end"`
+}
+
+// Suggestions is the result of scanning an article
+type Suggestions struct {
+	// Fields holds the most confident suggestion per field
+	Fields     map[string]Suggestion `json:"fields"`
+	Candidates []Suggestion          `json:"candidates"`
+}
+
+type score struct {
+	field    string
+	re       *regexp.Regexp
+	min, max float64
+}
+
+var scores = []score{
+	{"authorToefl", regexp.MustCompile(`(?i)(?:toefl|托福)(?:\s*ibt)?\s*[:：]?\s*(\d{2,3})\b`), 0, 120},
+	{"authorIelts", regexp.MustCompile(`(?i)(?:ielts|雅思)\s*[:：]?\s*(\d(?:\.[05])?)\b`), 1, 9},
+	{"authorGre", regexp.MustCompile(`(?i)\bgre(?:\s*general)?\s*[:：]?\s*(\d{3}(?:\s*[(（][^)）\n]{1,40}[)）])?)`), 260, 340},
+	{"authorGmat", regexp.MustCompile(`(?i)\bgmat\s*[:：]?\s*(\d{3})\b`), 200, 800},
+	{"authorTestdaf", regexp.MustCompile(`(?i)testdaf\s*[:：]?\s*((?:tdn\s*)?[345]{4}|(?:tdn\s*)?[345]\b|\d{2}\s*/\s*20)`), 0, 0},
+	{"authorGoethe", regexp.MustCompile(`(?i)(?:goethe(?:[-\s]*zertifikat)?|歌德)\s*[:：]?\s*([abc][12])\b`), 0, 0},
+}
+
+var gpaPattern = regexp.MustCompile(`(?i)(?:gpa|成績|平均)\s*[:：]?\s*(\d(?:\.\d{1,2})?\s*/\s*4(?:\.\d{1,2})?|\d\.\d{1,2}|\d{2}(?:\.\d{1,2})?\s*(?:/\s*100|%))`)
+
+var (
+	bachelorWords = []string{"大學部", "學士", "大學", "本科", "bachelor", "b.s.", "b.sc", "bsc", "bs", "undergrad"}
+	masterWords   = []string{"碩士", "研究所", "碩", "master", "m.s.", "m.sc", "msc", "ms", "graduate"}
+)
+
+// Suggest scans text and returns every candidate it found
+func Suggest(text string) Suggestions {
+	var candidates []Suggestion
+
+	for _, s := range scores {
+		for _, m := range s.re.FindAllStringSubmatchIndex(text, -1) {
+			value := strings.Join(strings.Fields(text[m[2]:m[3]]), " ")
+			if s.max > 0 {
+				n, err := strconv.ParseFloat(strings.Fields(value)[0], 64)
+				if err != nil || n < s.min || n > s.max {
+					continue
+				}
+			}
+			candidates = append(candidates, suggestion(text, s.field, strings.ToUpper(value[:1])+value[1:], 0.9, m[0], m[1]))
+		}
+	}
+
+	schools := findSchools(text)
+	for _, sm := range schools {
+		prefix := "authorBs"
+		if sm.master {
+			prefix = "authorMs"
+		}
+		candidates = append(candidates,
+			suggestion(text, prefix+"School", sm.school.Name, sm.confidence, sm.start, sm.end),
+			suggestion(text, prefix+"SchoolShort", sm.school.Short, sm.confidence, sm.start, sm.end),
+		)
+		if sm.department != "" {
+			candidates = append(candidates, suggestion(text, prefix+"Department", sm.department, sm.departmentConfidence, sm.start, sm.departmentEnd))
+		}
+	}
+
+	for _, m := range gpaPattern.FindAllStringSubmatchIndex(text, -1) {
+		value := strings.Join(strings.Fields(text[m[2]:m[3]]), "")
+		field, confidence := "authorBsGpa", 0.5
+		// a gpa belongs to the closest school mentioned before it
+		for _, sm := range schools {
+			if sm.end <= m[0] && m[0]-sm.end < 120 {
+				field, confidence = "authorBsGpa", 0.8
+				if sm.master {
+					field = "authorMsGpa"
+				}
+			}
+		}
+		candidates = append(candidates, suggestion(text, field, value, confidence, m[0], m[1]))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Field != candidates[j].Field {
+			return candidates[i].Field < candidates[j].Field
+		}
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	result := Suggestions{Fields: make(map[string]Suggestion), Candidates: candidates}
+	for _, c := range candidates {
+		if _, ok := result.Fields[c.Field]; !ok {
+			result.Fields[c.Field] = c
+		}
+	}
+	if result.Candidates == nil {
+		result.Candidates = []Suggestion{}
+	}
+
+	return result
+}
+
+type schoolMatch struct {
+	school               School
+	start, end           int
+	master               bool
+	confidence           float64
+	department           string
+	departmentEnd        int
+	departmentConfidence float64
+}
+
+var departmentSuffix = regexp.MustCompile(`^\s*([\p{Han}]{1,8}?)(?:學系|研究所|系|所)`)
+
+func findSchools(text string) []schoolMatch {
+	lower := toLower(text)
+	taken := make([]bool, len(text))
+
+	type alias struct {
+		school School
+		alias  string
+	}
+	var aliases []alias
+	for _, s := range Schools {
+		for _, a := range s.Aliases {
+			aliases = append(aliases, alias{s, a})
+		}
+	}
+	sort.SliceStable(aliases, func(i, j int) bool { return len(aliases[i].alias) > len(aliases[j].alias) })
+
+	var matches []schoolMatch
+	for _, a := range aliases {
+		needle := toLower(a.alias)
+		for from := 0; ; {
+			i := strings.Index(lower[from:], needle)
+			if i < 0 {
+				break
+			}
+			start, end := from+i, from+i+len(needle)
+			from = end
+
+			if taken[start] || taken[end-1] || !isWord(text, start, end) {
+				continue
+			}
+			for k := start; k < end; k++ {
+				taken[k] = true
+			}
+
+			sm := schoolMatch{school: a.school, start: start, end: end, confidence: 0.5}
+			sm.master, sm.confidence = degree(lower, start, end)
+			sm.department, sm.departmentEnd, sm.departmentConfidence = department(text, end)
+			if sm.department != "" && strings.Contains(text[end:sm.departmentEnd], "所") {
+				sm.master = true
+			}
+			matches = append(matches, sm)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	return matches
+}
+
+// degree looks for the closest word on the same line telling whether a school
+// is the bachelor or master school, preferring words in front of it
+func degree(lower string, start, end int) (master bool, confidence float64) {
+	lineStart := strings.LastIndex(lower[:start], "\n") + 1
+	lineEnd := strings.Index(lower[end:], "\n")
+	if lineEnd < 0 {
+		lineEnd = len(lower)
+	} else {
+		lineEnd += end
+	}
+
+	before := lower[max(lineStart, start-30):start]
+	after := lower[end:min(lineEnd, end+30)]
+
+	best, found := -1, false
+	for _, w := range masterWords {
+		if i := lastWord(before, w); i > best {
+			best, master, found = i, true, true
+		}
+	}
+	for _, w := range bachelorWords {
+		if i := lastWord(before, w); i > best {
+			best, master, found = i, false, true
+		}
+	}
+	if found {
+		return master, 0.85
+	}
+
+	best = len(after)
+	for _, w := range masterWords {
+		if i := firstWord(after, w); i >= 0 && i < best {
+			best, master, found = i, true, true
+		}
+	}
+	for _, w := range bachelorWords {
+		if i := firstWord(after, w); i >= 0 && i < best {
+			best, master, found = i, false, true
+		}
+	}
+	if found {
+		return master, 0.7
+	}
+
+	return false, 0.5
+}
+
+func department(text string, end int) (string, int, float64) {
+	rest := text[end:]
+	if m := departmentSuffix.FindStringSubmatchIndex(rest); m != nil {
+		return rest[m[2]:m[3]], end + m[1], 0.8
+	}
+	rest = strings.TrimLeft(rest, " ")
+	offset := len(text) - end - len(rest)
+	for _, d := range departments {
+		if strings.HasPrefix(rest, d) {
+			return d, end + offset + len(d), 0.6
+		}
+	}
+	return "", 0, 0
+}
+
+// isWord reports whether text[start:end] is not part of a longer latin word
+func isWord(text string, start, end int) bool {
+	first, _ := utf8.DecodeRuneInString(text[start:])
+	if first > unicode.MaxASCII {
+		return true
+	}
+	if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && (unicode.IsLetter(r) && r <= unicode.MaxASCII || unicode.IsDigit(r)) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && (unicode.IsLetter(r) && r <= unicode.MaxASCII || unicode.IsDigit(r)) {
+		return false
+	}
+	return true
+}
+
+// firstWord returns the index of the first whole word w in s, or -1
+func firstWord(s, w string) int {
+	for from := 0; ; {
+		i := strings.Index(s[from:], w)
+		if i < 0 {
+			return -1
+		}
+		if isWord(s, from+i, from+i+len(w)) {
+			return from + i
+		}
+		from += i + len(w)
+	}
+}
+
+// lastWord returns the index of the last whole word w in s, or -1
+func lastWord(s, w string) int {
+	for to := len(s); ; {
+		i := strings.LastIndex(s[:to], w)
+		if i < 0 {
+			return -1
+		}
+		if isWord(s, i, i+len(w)) {
+			return i
+		}
+		to = i
+	}
+}
+
+// toLower lowercases s rune by rune like strings.ToLower, but keeps runes
+// whose lower case has another UTF-8 length, like Ⱥ or the Kelvin sign, and
+// invalid bytes as they are, so byte offsets into the result are offsets into s
+func toLower(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if l := unicode.ToLower(r); r != utf8.RuneError && utf8.RuneLen(l) == size {
+			b.WriteRune(l)
+		} else {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+func suggestion(text, field, value string, confidence float64, start, end int) Suggestion {
+	return Suggestion{
+		Field:      field,
+		Value:      value,
+		Confidence: confidence,
+		Match:      text[start:end],
+		Start:      utf8.RuneCountInString(text[:start]),
+		End:        utf8.RuneCountInString(text[:end]),
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,64 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSuggestSchool(t *testing.T) {
+	tests := []struct {
+		name, text, field, value, match string
+	}{
+		{"ascii", "I studied at NTU", "authorBsSchool", "國立臺灣大學", "NTU"},
+		{"master", "碩士 清華大學 資工所", "authorMsSchool", "國立清華大學", "清華大學"},
+		// Ⱥ is two bytes long, its lower case three
+		{"longer lower case", "ȺȺȺ studied at NTU", "authorBsSchool", "國立臺灣大學", "NTU"},
+		// the Kelvin sign is three bytes long, its lower case one
+		{"shorter lower case", "KKK studied at NTU", "authorBsSchool", "國立臺灣大學", "NTU"},
+		{"invalid utf-8", "\xff\xfe studied at NTU", "authorBsSchool", "國立臺灣大學", "NTU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := Suggest(tt.text).Fields[tt.field]
+			if !ok {
+				t.Fatalf("no %s suggestion", tt.field)
+			}
+			if s.Value != tt.value || s.Match != tt.match {
+				t.Errorf("got value %q match %q, want %q and %q", s.Value, s.Match, tt.value, tt.match)
+			}
+			runes := []rune(tt.text)
+			if got := string(runes[s.Start:s.End]); utf8.ValidString(tt.text) && got != tt.match {
+				t.Errorf("runes %d to %d are %q, want %q", s.Start, s.End, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestSuggestNoPanic(t *testing.T) {
+	texts := []string{
+		strings.Repeat("Ⱥ", 50) + " 台大 bachelor GPA 3.8/4.3",
+		strings.Repeat("K", 50) + " 碩士 NTHU 資工所 TOEFL 100",
+		"İİİ NCKU ẞ masters",
+	}
+	for _, text := range texts {
+		for _, c := range Suggest(text).Candidates {
+			if !strings.Contains(text, c.Match) {
+				t.Errorf("%s match %q is not in the text", c.Field, c.Match)
+			}
+		}
+	}
+}
+
+func TestToLower(t *testing.T) {
+	for _, s := range []string{"ABC Straße", "ȺȺȺ", "KELVIN K", "İ", "\xffA"} {
+		got := toLower(s)
+		if len(got) != len(s) {
+			t.Errorf("toLower(%q) = %q, length %d, want %d", s, got, len(got), len(s))
+		}
+	}
+	if got := toLower("NTU Ä"); got != "ntu ä" {
+		t.Errorf("toLower = %q, want %q", got, "ntu ä")
+	}
+}
@@ -0,0 +1,53 @@
+package extract
+
+// School is a university applicants commonly come from. Aliases are matched
+// literally, longest first, so 陽明交大 wins over 交大.
+type School struct {
+	Name    string
+	Short   string
+	Aliases []string
+}
+
+// Schools lists Taiwanese and German universities found in application posts
+var Schools = []School{
+	{"國立臺灣大學", "台大", []string{"國立臺灣大學", "國立台灣大學", "臺灣大學", "台灣大學", "臺大", "台大", "NTU"}},
+	{"國立清華大學", "清大", []string{"國立清華大學", "清華大學", "清大", "NTHU"}},
+	{"國立陽明交通大學", "陽明交大", []string{"國立陽明交通大學", "陽明交通大學", "陽明交大", "交通大學", "交大", "NYCU", "NCTU"}},
+	{"國立成功大學", "成大", []string{"國立成功大學", "成功大學", "成大", "NCKU"}},
+	{"國立政治大學", "政大", []string{"國立政治大學", "政治大學", "政大", "NCCU"}},
+	{"國立中央大學", "中央", []string{"國立中央大學", "中央大學", "NCU"}},
+	{"國立中興大學", "中興", []string{"國立中興大學", "中興大學", "興大", "NCHU"}},
+	{"國立中山大學", "中山", []string{"國立中山大學", "中山大學", "NSYSU"}},
+	{"國立中正大學", "中正", []string{"國立中正大學", "中正大學", "CCU"}},
+	{"國立臺灣科技大學", "台科大", []string{"國立臺灣科技大學", "國立台灣科技大學", "臺灣科技大學", "台灣科技大學", "臺科大", "台科大", "NTUST"}},
+	{"國立臺北科技大學", "北科大", []string{"國立臺北科技大學", "國立台北科技大學", "臺北科技大學", "台北科技大學", "北科大", "北科", "NTUT"}},
+	{"國立臺灣師範大學", "師大", []string{"國立臺灣師範大學", "國立台灣師範大學", "臺灣師範大學", "台灣師範大學", "師大", "NTNU"}},
+	{"國立臺北大學", "北大", []string{"國立臺北大學", "國立台北大學", "臺北大學", "台北大學", "NTPU"}},
+	{"國立東華大學", "東華", []string{"國立東華大學", "東華大學", "NDHU"}},
+	{"國立高雄科技大學", "高科大", []string{"國立高雄科技大學", "高雄科技大學", "高科大", "NKUST"}},
+	{"長庚大學", "長庚", []string{"長庚大學", "CGU"}},
+	{"輔仁大學", "輔大", []string{"輔仁大學", "輔大", "FJU"}},
+	{"東吳大學", "東吳", []string{"東吳大學", "東吳"}},
+	{"淡江大學", "淡江", []string{"淡江大學", "淡江", "TKU"}},
+	{"逢甲大學", "逢甲", []string{"逢甲大學", "逢甲", "FCU"}},
+	{"元智大學", "元智", []string{"元智大學", "元智", "YZU"}},
+	{"中原大學", "中原", []string{"中原大學", "中原", "CYCU"}},
+	{"東海大學", "東海", []string{"東海大學", "東海", "THU"}},
+	{"文化大學", "文化", []string{"中國文化大學", "文化大學"}},
+	{"Technical University of Munich", "TUM", []string{"Technical University of Munich", "Technische Universität München", "TU München", "TU Munich", "TUM", "慕尼黑工業大學", "慕工大"}},
+	{"RWTH Aachen University", "RWTH", []string{"RWTH Aachen University", "RWTH Aachen", "RWTH", "亞琛工業大學", "亞琛工大"}},
+	{"Karlsruhe Institute of Technology", "KIT", []string{"Karlsruhe Institute of Technology", "KIT", "卡爾斯魯厄理工學院"}},
+	{"Technische Universität Berlin", "TU Berlin", []string{"Technische Universität Berlin", "TU Berlin", "柏林工業大學"}},
+	{"Technische Universität Dresden", "TU Dresden", []string{"Technische Universität Dresden", "TU Dresden", "德勒斯登工業大學"}},
+	{"Technische Universität Darmstadt", "TU Darmstadt", []string{"Technische Universität Darmstadt", "TU Darmstadt", "達姆施塔特工業大學"}},
+	{"University of Stuttgart", "Uni Stuttgart", []string{"University of Stuttgart", "Universität Stuttgart", "Uni Stuttgart", "斯圖加特大學"}},
+	{"Ludwig-Maximilians-Universität München", "LMU", []string{"Ludwig-Maximilians-Universität München", "LMU", "慕尼黑大學"}},
+	{"Heidelberg University", "Heidelberg", []string{"Heidelberg University", "Universität Heidelberg", "海德堡大學"}},
+}
+
+// departments are matched right after a school when no 系/所 suffix follows
+var departments = []string{
+	"電機", "資工", "資管", "資訊", "電子", "光電", "機械", "化工", "材料", "土木", "工科", "工管", "工工",
+	"物理", "化學", "數學", "統計", "生科", "醫工", "環工", "應數", "應力", "經濟", "財金", "會計", "企管",
+	"國企", "外文", "建築", "地理", "心理", "社會", "政治", "法律", "外交", "德文", "大氣",
+}
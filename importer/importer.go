@@ -0,0 +1,400 @@
+// Package importer validates CSV files of universities and courses and
+// applies the valid rows through the models layer in a single transaction.
+package importer
+
+import (
+	"backend/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Universities = "universities"
+	Courses      = "courses"
+)
+
+var universityColumns = []string{"id", "name_en", "name_ch", "city", "is_from_daad", "is_tu9", "is_u15", "qs_ranking", "link"}
+
+var courseColumns = []string{"id", "university_id", "course_type", "name_en", "name_en_short", "name_ch", "name_ch_short",
+	"tuition_fees", "beginning", "subject", "daadlink", "is_elearning", "application_deadline",
+	"is_complete_online_possible", "programme_duration", "is_from_daad"}
+
+// RowResult is the outcome of one csv row, line numbers count the header as line 1
+type RowResult struct {
+	Line   int      `json:"line"`
+	ID     int      `json:"id,omitempty"`
+	Action string   `json:"action"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Report summarizes an import run
+type Report struct {
+	Kind     string      `json:"kind"`
+	DryRun   bool        `json:"dry_run"`
+	Total    int         `json:"total"`
+	Inserted int         `json:"inserted"`
+	Updated  int         `json:"updated"`
+	Invalid  int         `json:"invalid"`
+	Rows     []RowResult `json:"rows"`
+}
+
+// Run imports the csv in r. With dryRun set nothing is written and the report
+// shows what would be inserted and updated.
+func Run(m *models.DBModel, kind string, r io.Reader, dryRun bool) (*Report, error) {
+	var columns []string
+	switch kind {
+	case Universities:
+		columns = universityColumns
+	case Courses:
+		columns = courseColumns
+	default:
+		return nil, fmt.Errorf("unknown import kind %q", kind)
+	}
+
+	records, err := readCSV(r, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Kind: kind, DryRun: dryRun, Total: len(records)}
+
+	if kind == Universities {
+		err = importUniversities(m, records, report)
+	} else {
+		err = importCourses(m, records, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range report.Rows {
+		switch row.Action {
+		case "insert":
+			report.Inserted++
+		case "update":
+			report.Updated++
+		default:
+			report.Invalid++
+		}
+	}
+
+	return report, nil
+}
+
+type record struct {
+	line   int
+	fields map[string]string
+	errors []string
+}
+
+func readCSV(r io.Reader, columns []string) ([]*record, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty csv file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		index[h] = i
+	}
+
+	var missing []string
+	for _, c := range columns {
+		if _, ok := index[c]; !ok && c != "id" {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing columns: %s", strings.Join(missing, ", "))
+	}
+
+	var records []*record
+	line := 1
+	for {
+		values, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, err
+		}
+
+		rec := &record{line: line, fields: make(map[string]string)}
+		for _, c := range columns {
+			if i, ok := index[c]; ok && i < len(values) {
+				rec.fields[c] = strings.TrimSpace(values[i])
+			}
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+func (rec *record) int(name string, required bool) int {
+	v := rec.fields[name]
+	if v == "" {
+		if required {
+			rec.errors = append(rec.errors, name+" is required")
+		}
+		return 0
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		rec.errors = append(rec.errors, fmt.Sprintf("%s: %q is not a number", name, v))
+	}
+	return i
+}
+
+func (rec *record) bool(name string) bool {
+	v := rec.fields[name]
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		rec.errors = append(rec.errors, fmt.Sprintf("%s: %q is not a boolean", name, v))
+	}
+	return b
+}
+
+func (rec *record) string(name string, required bool) string {
+	v := rec.fields[name]
+	if v == "" && required {
+		rec.errors = append(rec.errors, name+" is required")
+	}
+	return v
+}
+
+func (rec *record) result(id int, action string) RowResult {
+	if len(rec.errors) > 0 {
+		return RowResult{Line: rec.line, ID: id, Action: "skip", Errors: rec.errors}
+	}
+	return RowResult{Line: rec.line, ID: id, Action: action}
+}
+
+// nextID hands out ids above every id already used in the table or the file
+type nextID struct {
+	max int
+}
+
+func (n *nextID) see(id int) {
+	if id > n.max {
+		n.max = id
+	}
+}
+
+func (n *nextID) next() int {
+	n.max++
+	return n.max
+}
+
+func importUniversities(m *models.DBModel, records []*record, report *Report) error {
+	existing, err := m.UniversityIndex()
+	if err != nil {
+		return err
+	}
+
+	inserts, updates := planUniversities(existing, records, report)
+
+	if report.DryRun || len(inserts)+len(updates) == 0 {
+		return nil
+	}
+	return m.ImportUniversities(inserts, updates)
+}
+
+// planUniversities checks the records against each other and the existing
+// universities and adds a row result for each to report
+func planUniversities(existing []models.University, records []*record, report *Report) (inserts, updates []models.University) {
+	var ids nextID
+	byID := make(map[int]bool)
+	byName := make(map[string]int)
+	for _, u := range existing {
+		byID[u.ID] = true
+		byName[strings.ToLower(u.NameEn)] = u.ID
+		ids.see(u.ID)
+	}
+
+	var rows []models.University
+	for _, rec := range records {
+		var u models.University
+		u.ID = rec.int("id", false)
+		u.NameEn = rec.string("name_en", true)
+		u.NameCh = rec.fields["name_ch"]
+		u.City = rec.fields["city"]
+		u.IsFromDaad = rec.bool("is_from_daad")
+		u.IsTu9 = rec.bool("is_tu9")
+		u.IsU15 = rec.bool("is_u15")
+		u.QsRanking = rec.int("qs_ranking", false)
+		u.Link = rec.fields["link"]
+		if u.ID > 0 {
+			ids.see(u.ID)
+		}
+		rows = append(rows, u)
+	}
+
+	seenIDs := make(map[int]int)
+	seenNames := make(map[string]int)
+	for i, rec := range records {
+		u := rows[i]
+		name := strings.ToLower(u.NameEn)
+
+		if line, ok := seenNames[name]; ok && name != "" {
+			rec.errors = append(rec.errors, fmt.Sprintf("duplicate of line %d", line))
+		}
+		if u.ID == 0 {
+			u.ID = byName[name]
+		}
+		if line, ok := seenIDs[u.ID]; ok && u.ID != 0 {
+			rec.errors = append(rec.errors, fmt.Sprintf("id %d already used on line %d", u.ID, line))
+		}
+		if id, ok := byName[name]; ok && id != u.ID {
+			rec.errors = append(rec.errors, fmt.Sprintf("name_en already used by university %d", id))
+		}
+		seenNames[name] = rec.line
+
+		if len(rec.errors) > 0 {
+			report.Rows = append(report.Rows, rec.result(u.ID, ""))
+			continue
+		}
+
+		action := "update"
+		if !byID[u.ID] {
+			action = "insert"
+			if u.ID == 0 {
+				u.ID = ids.next()
+			}
+			u.CreatedAt = time.Now()
+			inserts = append(inserts, u)
+		} else {
+			updates = append(updates, u)
+		}
+		seenIDs[u.ID] = rec.line
+		report.Rows = append(report.Rows, rec.result(u.ID, action))
+	}
+
+	return inserts, updates
+}
+
+func importCourses(m *models.DBModel, records []*record, report *Report) error {
+	universities, err := m.UniversityIndex()
+	if err != nil {
+		return err
+	}
+	existing, err := m.CourseIndex()
+	if err != nil {
+		return err
+	}
+
+	inserts, updates := planCourses(universities, existing, records, report)
+
+	if report.DryRun || len(inserts)+len(updates) == 0 {
+		return nil
+	}
+	return m.ImportCourses(inserts, updates)
+}
+
+// planCourses checks the records against each other, the universities and
+// the existing courses and adds a row result for each to report
+func planCourses(universities []models.University, existing []models.Course, records []*record, report *Report) (inserts, updates []models.Course) {
+	universityIDs := make(map[int]bool)
+	for _, u := range universities {
+		universityIDs[u.ID] = true
+	}
+
+	var ids nextID
+	byID := make(map[int]bool)
+	byKey := make(map[string]int)
+	for _, c := range existing {
+		byID[c.ID] = true
+		byKey[courseKey(c)] = c.ID
+		ids.see(c.ID)
+	}
+
+	var rows []models.Course
+	for _, rec := range records {
+		var c models.Course
+		c.ID = rec.int("id", false)
+		c.UniversityId = strconv.Itoa(rec.int("university_id", true))
+		c.CourseType = strconv.Itoa(rec.int("course_type", true))
+		c.NameEn = rec.string("name_en", true)
+		c.NameEnShort = rec.fields["name_en_short"]
+		c.NameCh = rec.fields["name_ch"]
+		c.NameChShort = rec.fields["name_ch_short"]
+		c.TuitionFees = rec.fields["tuition_fees"]
+		c.Beginning = rec.fields["beginning"]
+		c.Subject = rec.fields["subject"]
+		c.Daadlink = rec.fields["daadlink"]
+		c.IsElearning = rec.bool("is_elearning")
+		c.ApplicationDeadline = rec.fields["application_deadline"]
+		c.IsCompleteOnlinePossible = rec.bool("is_complete_online_possible")
+		c.ProgrammeDuration = rec.fields["programme_duration"]
+		c.IsFromDaad = rec.bool("is_from_daad")
+		if c.ID > 0 {
+			ids.see(c.ID)
+		}
+		rows = append(rows, c)
+	}
+
+	seenIDs := make(map[int]int)
+	seenKeys := make(map[string]int)
+	for i, rec := range records {
+		c := rows[i]
+		key := courseKey(c)
+
+		if uid, _ := strconv.Atoi(c.UniversityId); uid != 0 && !universityIDs[uid] {
+			rec.errors = append(rec.errors, fmt.Sprintf("university_id %d does not exist", uid))
+		}
+		if line, ok := seenKeys[key]; ok {
+			rec.errors = append(rec.errors, fmt.Sprintf("duplicate of line %d", line))
+		}
+		if c.ID == 0 {
+			c.ID = byKey[key]
+		}
+		if line, ok := seenIDs[c.ID]; ok && c.ID != 0 {
+			rec.errors = append(rec.errors, fmt.Sprintf("id %d already used on line %d", c.ID, line))
+		}
+		if id, ok := byKey[key]; ok && id != c.ID {
+			rec.errors = append(rec.errors, fmt.Sprintf("same university, type and name as course %d", id))
+		}
+		seenKeys[key] = rec.line
+
+		if len(rec.errors) > 0 {
+			report.Rows = append(report.Rows, rec.result(c.ID, ""))
+			continue
+		}
+
+		action := "update"
+		if !byID[c.ID] {
+			action = "insert"
+			if c.ID == 0 {
+				c.ID = ids.next()
+			}
+			c.CreatedAt = time.Now()
+			inserts = append(inserts, c)
+		} else {
+			updates = append(updates, c)
+		}
+		seenIDs[c.ID] = rec.line
+		report.Rows = append(report.Rows, rec.result(c.ID, action))
+	}
+
+	return inserts, updates
+}
+
+func courseKey(c models.Course) string {
+	return c.UniversityId + "|" + c.CourseType + "|" + strings.ToLower(c.NameEn)
+}
@@ -0,0 +1,313 @@
+package importer
+
+import (
+	"backend/models"
+	"database/sql"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDB connects to the database of TEST_DATABASE_URL, which must hold the
+// schema with every migration applied. The test is skipped when the variable
+// is not set.
+func testDB(t *testing.T) *models.DBModel {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	connector, err := models.NewConnector(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+
+	return &models.DBModel{DB: db}
+}
+
+func readRecords(t *testing.T, columns []string, text string) []*record {
+	t.Helper()
+
+	records, err := readCSV(strings.NewReader(text), columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []map[string]string
+		err  string
+	}{
+		{name: "empty", text: "", err: "empty csv file"},
+		{name: "missing columns", text: "id,name_en,city\n", err: "missing columns: name_ch, is_from_daad, is_tu9, is_u15, qs_ranking, link"},
+		{
+			name: "header with bom, case and spaces, no id column",
+			text: "\ufeffName_EN, name_ch ,city,is_from_daad,is_tu9,is_u15,qs_ranking,link,extra\n" +
+				"  TU Berlin ,柏林工业大学,Berlin,true,true,false,150,https://www.tu.berlin,ignored\n",
+			want: []map[string]string{{
+				"name_en": "TU Berlin", "name_ch": "柏林工业大学", "city": "Berlin", "is_from_daad": "true",
+				"is_tu9": "true", "is_u15": "false", "qs_ranking": "150", "link": "https://www.tu.berlin",
+			}},
+		},
+		{
+			name: "quoted field",
+			text: "id,name_en,name_ch,city,is_from_daad,is_tu9,is_u15,qs_ranking,link\n" +
+				`3,"Universität ""Bonn"", NRW",,Bonn,,,,,` + "\n",
+			want: []map[string]string{{
+				"id": "3", "name_en": `Universität "Bonn", NRW`, "name_ch": "", "city": "Bonn", "is_from_daad": "",
+				"is_tu9": "", "is_u15": "", "qs_ranking": "", "link": "",
+			}},
+		},
+		{
+			name: "wrong number of fields",
+			text: "id,name_en,name_ch,city,is_from_daad,is_tu9,is_u15,qs_ranking,link\n1,a\n",
+			err:  "wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := readCSV(strings.NewReader(tt.text), universityColumns)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("got %v, want an error containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []map[string]string
+			for i, rec := range records {
+				if rec.line != i+2 {
+					t.Errorf("record %d is on line %d, want %d", i, rec.line, i+2)
+				}
+				got = append(got, rec.fields)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordAccessors(t *testing.T) {
+	rec := &record{fields: map[string]string{
+		"n": "42", "bad_n": "4x", "b": "TRUE", "bad_b": "yes", "s": "text",
+	}}
+
+	if got := rec.int("n", true); got != 42 {
+		t.Errorf("int: got %d", got)
+	}
+	if got := rec.int("empty", false); got != 0 {
+		t.Errorf("optional int: got %d", got)
+	}
+	if got := rec.bool("b"); !got {
+		t.Error("bool: got false")
+	}
+	if got := rec.bool("empty"); got {
+		t.Error("missing bool: got true")
+	}
+	if got := rec.string("s", true); got != "text" {
+		t.Errorf("string: got %q", got)
+	}
+	if len(rec.errors) != 0 {
+		t.Fatalf("valid fields reported %v", rec.errors)
+	}
+	if got := rec.result(7, "insert"); !reflect.DeepEqual(got, RowResult{ID: 7, Action: "insert"}) {
+		t.Errorf("result: got %+v", got)
+	}
+
+	rec.int("bad_n", false)
+	rec.int("missing_n", true)
+	rec.bool("bad_b")
+	rec.string("missing_s", true)
+
+	want := []string{
+		`bad_n: "4x" is not a number`,
+		"missing_n is required",
+		`bad_b: "yes" is not a boolean`,
+		"missing_s is required",
+	}
+	if !reflect.DeepEqual(rec.errors, want) {
+		t.Errorf("got errors %q, want %q", rec.errors, want)
+	}
+	if got := rec.result(7, "insert"); got.Action != "skip" || len(got.Errors) != 4 {
+		t.Errorf("result with errors: got %+v", got)
+	}
+}
+
+func TestNextID(t *testing.T) {
+	var ids nextID
+	for _, id := range []int{3, 9, 1} {
+		ids.see(id)
+	}
+	if a, b := ids.next(), ids.next(); a != 10 || b != 11 {
+		t.Errorf("got %d and %d, want 10 and 11", a, b)
+	}
+}
+
+func TestPlanUniversities(t *testing.T) {
+	existing := []models.University{{ID: 1, NameEn: "TU Berlin"}, {ID: 5, NameEn: "LMU"}}
+	records := readRecords(t, universityColumns, `id,name_en,name_ch,city,is_from_daad,is_tu9,is_u15,qs_ranking,link
+,New Uni,,Bonn,true,false,false,200,
+,tu berlin,,Berlin,,true,,,
+7,Other,,,,,,,
+5,Renamed LMU,,,,,,,
+9,TU Berlin,,,,,,,
+,New Uni,,,,,,,
+7,Third,,,,,,,
+,,,,,,,,
+,Bad,,,maybe,,,x,
+`)
+
+	report := &Report{}
+	inserts, updates := planUniversities(existing, records, report)
+
+	want := []RowResult{
+		{Line: 2, ID: 10, Action: "insert"},
+		{Line: 3, ID: 1, Action: "update"},
+		{Line: 4, ID: 7, Action: "insert"},
+		{Line: 5, ID: 5, Action: "update"},
+		{Line: 6, ID: 9, Action: "skip", Errors: []string{"duplicate of line 3", "name_en already used by university 1"}},
+		{Line: 7, Action: "skip", Errors: []string{"duplicate of line 2"}},
+		{Line: 8, ID: 7, Action: "skip", Errors: []string{"id 7 already used on line 4"}},
+		{Line: 9, Action: "skip", Errors: []string{"name_en is required"}},
+		{Line: 10, Action: "skip", Errors: []string{`is_from_daad: "maybe" is not a boolean`, `qs_ranking: "x" is not a number`}},
+	}
+	if !reflect.DeepEqual(report.Rows, want) {
+		t.Errorf("got rows\n%+v\nwant\n%+v", report.Rows, want)
+	}
+
+	if len(inserts) != 2 || inserts[0].ID != 10 || inserts[0].City != "Bonn" || !inserts[0].IsFromDaad || inserts[0].QsRanking != 200 {
+		t.Errorf("got inserts %+v", inserts)
+	}
+	if len(updates) != 2 || updates[0].ID != 1 || updates[0].NameEn != "tu berlin" || !updates[0].IsTu9 {
+		t.Errorf("got updates %+v", updates)
+	}
+}
+
+func TestPlanCourses(t *testing.T) {
+	universities := []models.University{{ID: 1}, {ID: 2}}
+	existing := []models.Course{{ID: 3, UniversityId: "1", CourseType: "2", NameEn: "Informatics"}}
+	records := readRecords(t, courseColumns, `id,university_id,course_type,name_en,name_en_short,name_ch,name_ch_short,tuition_fees,beginning,subject,daadlink,is_elearning,application_deadline,is_complete_online_possible,programme_duration,is_from_daad
+,1,2,informatics,,,,none,,,,,,,4 semesters,
+,2,1,Physics,,,,,,,,,,,,
+10,1,1,Maths,,,,,,,,true,,,,
+,9,1,Chemistry,,,,,,,,,,,,
+,2,1,PHYSICS,,,,,,,,,,,,
+3,1,1,Other,,,,,,,,,,,,
+,,,Nameless,,,,,,,,,,,,
+12,1,2,Informatics,,,,,,,,,,,,
+`)
+
+	report := &Report{}
+	inserts, updates := planCourses(universities, existing, records, report)
+
+	want := []RowResult{
+		{Line: 2, ID: 3, Action: "update"},
+		{Line: 3, ID: 13, Action: "insert"},
+		{Line: 4, ID: 10, Action: "insert"},
+		{Line: 5, Action: "skip", Errors: []string{"university_id 9 does not exist"}},
+		{Line: 6, Action: "skip", Errors: []string{"duplicate of line 3"}},
+		{Line: 7, ID: 3, Action: "skip", Errors: []string{"id 3 already used on line 2"}},
+		{Line: 8, Action: "skip", Errors: []string{"university_id is required", "course_type is required"}},
+		{Line: 9, ID: 12, Action: "skip", Errors: []string{"duplicate of line 2", "same university, type and name as course 3"}},
+	}
+	if !reflect.DeepEqual(report.Rows, want) {
+		t.Errorf("got rows\n%+v\nwant\n%+v", report.Rows, want)
+	}
+
+	if len(inserts) != 2 || inserts[0].UniversityId != "2" || inserts[0].CourseType != "1" || !inserts[1].IsElearning {
+		t.Errorf("got inserts %+v", inserts)
+	}
+	if len(updates) != 1 || updates[0].TuitionFees != "none" || updates[0].ProgrammeDuration != "4 semesters" {
+		t.Errorf("got updates %+v", updates)
+	}
+}
+
+func TestRunUnknownKind(t *testing.T) {
+	_, err := Run(nil, "articles", strings.NewReader(""), true)
+	if err == nil || !strings.Contains(err.Error(), `unknown import kind "articles"`) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	m := testDB(t)
+
+	name := fmt.Sprintf("Import Test University %d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		m.DB.Exec(`delete from course where university_id in (select id from university where name_en = $1)`, name)
+		m.DB.Exec(`delete from university where name_en = $1`, name)
+	})
+
+	universities := "name_en,name_ch,city,is_from_daad,is_tu9,is_u15,qs_ranking,link\n" + name + ",,Test City,false,false,false,0,\n"
+
+	report, err := Run(m, Universities, strings.NewReader(universities), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || report.Total != 1 || report.Inserted != 1 {
+		t.Fatalf("dry run: got %+v", report)
+	}
+	index, err := m.UniversityIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range index {
+		if u.NameEn == name {
+			t.Fatal("dry run inserted the university")
+		}
+	}
+
+	report, err = Run(m, Universities, strings.NewReader(universities), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Inserted != 1 {
+		t.Fatalf("import: got %+v", report)
+	}
+	id := report.Rows[0].ID
+
+	// the second run finds the university by name
+	report, err = Run(m, Universities, strings.NewReader(universities), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Updated != 1 || report.Rows[0].ID != id {
+		t.Errorf("second run: got %+v, want an update of %d", report, id)
+	}
+
+	courses := "university_id,course_type,name_en,name_en_short,name_ch,name_ch_short,tuition_fees,beginning,subject,daadlink,is_elearning,application_deadline,is_complete_online_possible,programme_duration,is_from_daad\n" +
+		fmt.Sprintf("%d,2,Test Course,,,,,,,,,,,,\n", id) +
+		fmt.Sprintf("%d,2,test course,,,,,,,,,,,,\n", id)
+
+	report, err = Run(m, Courses, strings.NewReader(courses), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Inserted != 1 || report.Invalid != 1 {
+		t.Errorf("courses: got %+v", report)
+	}
+
+	report, err = Run(m, Courses, strings.NewReader(courses), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Updated != 1 || report.Invalid != 1 {
+		t.Errorf("courses again: got %+v", report)
+	}
+}
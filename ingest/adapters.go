@@ -0,0 +1,152 @@
+package ingest
+
+import (
+	"backend/models"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// PTT parses pages saved from www.ptt.cc
+type PTT struct{}
+
+func (PTT) Source() string { return "PTT" }
+
+func (PTT) Match(u *url.URL) bool { return hostIs(u, "ptt.cc") }
+
+var taipei = time.FixedZone("CST", 8*60*60)
+
+func (PTT) Parse(p *Page) (*models.ContentDraft, error) {
+	main := p.Find(func(n *html.Node) bool { return attr(n, "id") == "main-content" })
+	if main == nil {
+		return nil, errors.New("not a ptt article page")
+	}
+
+	var d models.ContentDraft
+
+	walk(main, func(n *html.Node) bool {
+		if !hasClass(n, "article-metaline") {
+			return true
+		}
+		var tag, value string
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if hasClass(c, "article-meta-tag") {
+				tag = text(c)
+			}
+			if hasClass(c, "article-meta-value") {
+				value = text(c)
+			}
+		}
+		switch tag {
+		case "作者":
+			// "user (nickname)"
+			d.Author = strings.TrimSpace(strings.SplitN(value, " (", 2)[0])
+		case "標題":
+			d.Title = value
+		case "時間":
+			if t, err := time.ParseInLocation("Mon Jan _2 15:04:05 2006", value, taipei); err == nil {
+				d.PublishedAt = &t
+			}
+		}
+		return true
+	})
+
+	body := textExcept(main, func(n *html.Node) bool {
+		return hasClass(n, "article-metaline") || hasClass(n, "article-metaline-right") || hasClass(n, "push")
+	})
+	// cut the signature and the posting footer
+	if i := strings.Index(body, "※ 發信站"); i >= 0 {
+		body = body[:i]
+	}
+	body = strings.TrimSpace(body)
+	body = strings.TrimSpace(strings.TrimSuffix(body, "--"))
+	d.Content = body
+
+	if d.Title == "" {
+		d.Title = p.Title()
+	}
+
+	return &d, nil
+}
+
+// Dcard parses pages saved from www.dcard.tw
+type Dcard struct{}
+
+func (Dcard) Source() string { return "Dcard" }
+
+func (Dcard) Match(u *url.URL) bool { return hostIs(u, "dcard.tw") }
+
+func (Dcard) Parse(p *Page) (*models.ContentDraft, error) {
+	d := generic(p)
+	d.Title = strings.TrimSuffix(d.Title, " | Dcard")
+	return d, nil
+}
+
+// Medium parses pages saved from medium.com, custom domains need the source passed explicitly
+type Medium struct{}
+
+func (Medium) Source() string { return "Medium" }
+
+func (Medium) Match(u *url.URL) bool { return hostIs(u, "medium.com") }
+
+func (Medium) Parse(p *Page) (*models.ContentDraft, error) {
+	d := generic(p)
+	d.Title = strings.TrimSuffix(d.Title, " | Medium")
+	return d, nil
+}
+
+// Blog is the fallback for personal blogs using common article markup
+type Blog struct{}
+
+func (Blog) Source() string { return "Blog" }
+
+func (Blog) Match(u *url.URL) bool { return true }
+
+func (Blog) Parse(p *Page) (*models.ContentDraft, error) {
+	return generic(p), nil
+}
+
+var bodyClasses = []string{"entry-content", "post-content", "post-body", "article-content"}
+
+// generic reads Open Graph metadata and the text of the main article element
+func generic(p *Page) *models.ContentDraft {
+	d := models.ContentDraft{
+		Title:       p.Title(),
+		Author:      p.Meta("author"),
+		PublishedAt: p.Published(),
+	}
+	if d.Author == "" {
+		d.Author = p.Meta("article:author")
+	}
+
+	body := p.Find(func(n *html.Node) bool { return n.Data == "article" })
+	if body == nil {
+		body = p.Find(func(n *html.Node) bool {
+			for _, c := range bodyClasses {
+				if hasClass(n, c) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if body == nil {
+		body = p.Find(func(n *html.Node) bool { return n.Data == "main" })
+	}
+	if body == nil {
+		body = p.Find(func(n *html.Node) bool { return n.Data == "body" })
+	}
+	if body != nil {
+		d.Content = text(body)
+	}
+
+	return &d
+}
+
+func hostIs(u *url.URL, domain string) bool {
+	host := strings.ToLower(u.Hostname())
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
@@ -0,0 +1,129 @@
+// Package ingest turns saved article pages into content drafts. Each source
+// has an Adapter; drafts are de-duplicated by their canonical link.
+package ingest
+
+import (
+	"backend/models"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Adapter parses pages of one source
+type Adapter interface {
+	// Source is the name stored in content.source, e.g. PTT
+	Source() string
+	// Match reports whether the link belongs to this source
+	Match(u *url.URL) bool
+	// Parse fills a draft from the page
+	Parse(p *Page) (*models.ContentDraft, error)
+}
+
+// Adapters are tried in order, the generic blog adapter matches everything
+var Adapters = []Adapter{PTT{}, Dcard{}, Medium{}, Blog{}}
+
+// ErrNoLink is returned when neither the caller nor the page gives a link
+var ErrNoLink = errors.New("the page has no canonical link, pass the article link")
+
+// Parse reads a saved page. link and source are optional: link falls back to
+// the page's canonical link and source to the adapter matching the link.
+func Parse(r io.Reader, link, source string) (*models.ContentDraft, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPage(doc)
+	if link == "" {
+		link = p.Canonical()
+	}
+	if link == "" {
+		return nil, ErrNoLink
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	p.URL = u
+
+	adapter, err := pick(u, source)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := adapter.Parse(p)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Source = adapter.Source()
+	d.Link = link
+	d.CanonicalLink = Canonical(link)
+	d.Title = clean(d.Title)
+	d.Author = clean(d.Author)
+	d.Content = strings.TrimSpace(d.Content)
+
+	if d.Title == "" && d.Content == "" {
+		return nil, errors.New("no article found in the page")
+	}
+
+	return d, nil
+}
+
+func pick(u *url.URL, source string) (Adapter, error) {
+	for _, a := range Adapters {
+		if source != "" && strings.EqualFold(a.Source(), source) {
+			return a, nil
+		}
+		if source == "" && a.Match(u) {
+			return a, nil
+		}
+	}
+	return nil, errors.New("unknown source " + source)
+}
+
+// trackingParams are dropped from canonical links next to utm_*. Only keys
+// known to be added by ad and social networks are listed, as a generic name
+// like ref may tell two articles apart.
+var trackingParams = []string{"fbclid", "gclid", "msclkid", "igshid"}
+
+// Canonical normalizes a link so the same article saved from different
+// places compares equal: https, no www. or m. host prefix, no fragment,
+// no tracking parameters and no trailing slash.
+func Canonical(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(link)
+	}
+
+	u.Scheme = "https"
+	u.Host = strings.ToLower(u.Host)
+	u.Host = strings.TrimPrefix(u.Host, "www.")
+	u.Host = strings.TrimPrefix(u.Host, "m.")
+	u.Fragment = ""
+	u.User = nil
+
+	q := u.Query()
+	for key := range q {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			q.Del(key)
+		}
+	}
+	for _, key := range trackingParams {
+		q.Del(key)
+	}
+	u.RawQuery = q.Encode()
+
+	u.Path = strings.TrimRight(u.Path, "/")
+
+	return u.String()
+}
+
+// clean collapses whitespace within a single line value
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
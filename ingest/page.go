@@ -0,0 +1,183 @@
+package ingest
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Page is a parsed html document with the metadata adapters usually need
+type Page struct {
+	Doc  *html.Node
+	URL  *url.URL
+	meta map[string]string
+}
+
+func newPage(doc *html.Node) *Page {
+	p := &Page{Doc: doc, meta: make(map[string]string)}
+
+	walk(doc, func(n *html.Node) bool {
+		switch n.Data {
+		case "meta":
+			key := attr(n, "property")
+			if key == "" {
+				key = attr(n, "name")
+			}
+			if key != "" {
+				if _, ok := p.meta[key]; !ok {
+					p.meta[key] = attr(n, "content")
+				}
+			}
+		case "link":
+			if attr(n, "rel") == "canonical" {
+				p.meta["canonical"] = attr(n, "href")
+			}
+		case "title":
+			p.meta["title"] = text(n)
+		}
+		return true
+	})
+
+	return p
+}
+
+// Meta returns the content of a meta tag by property or name
+func (p *Page) Meta(key string) string {
+	return strings.TrimSpace(p.meta[key])
+}
+
+// Canonical returns the page's canonical or og:url link
+func (p *Page) Canonical() string {
+	if c := p.Meta("canonical"); c != "" {
+		return c
+	}
+	return p.Meta("og:url")
+}
+
+// Title returns og:title or the document title
+func (p *Page) Title() string {
+	if t := p.Meta("og:title"); t != "" {
+		return t
+	}
+	return p.Meta("title")
+}
+
+// Published returns the article:published_time meta or the first <time datetime>
+func (p *Page) Published() *time.Time {
+	if t := parseTime(p.Meta("article:published_time")); t != nil {
+		return t
+	}
+
+	var published *time.Time
+	walk(p.Doc, func(n *html.Node) bool {
+		if published == nil && n.Data == "time" {
+			published = parseTime(attr(n, "datetime"))
+		}
+		return published == nil
+	})
+	return published
+}
+
+// Find returns the first element matching the predicate
+func (p *Page) Find(match func(n *html.Node) bool) *html.Node {
+	var found *html.Node
+	walk(p.Doc, func(n *html.Node) bool {
+		if found == nil && match(n) {
+			found = n
+		}
+		return found == nil
+	})
+	return found
+}
+
+func parseTime(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// walk visits element nodes depth first until fn returns false
+func walk(n *html.Node, fn func(n *html.Node) bool) bool {
+	if n.Type == html.ElementNode && !fn(n) {
+		return false
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if !walk(c, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+var skipped = map[string]bool{"script": true, "style": true, "noscript": true, "nav": true, "header": true, "footer": true, "aside": true, "form": true, "button": true}
+
+var blocks = map[string]bool{"p": true, "div": true, "br": true, "li": true, "h1": true, "h2": true, "h3": true, "h4": true, "blockquote": true, "pre": true, "section": true, "tr": true}
+
+// text returns the readable text below n, block elements end a line
+func text(n *html.Node) string {
+	return textExcept(n, func(*html.Node) bool { return false })
+}
+
+// textExcept is text leaving out the elements skip returns true for
+func textExcept(n *html.Node, skip func(n *html.Node) bool) string {
+	var buf strings.Builder
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			buf.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if skipped[n.Data] || skip(n) {
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+		if n.Type == html.ElementNode && blocks[n.Data] {
+			buf.WriteString("\n")
+		}
+	}
+	collect(n)
+
+	var lines []string
+	blank := false
+	for _, l := range strings.Split(buf.String(), "\n") {
+		l = strings.TrimRight(l, " \t\r")
+		if strings.TrimSpace(l) == "" {
+			if !blank && len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		lines = append(lines, l)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,217 @@
+// Package linkcheck checks external links with bounded concurrency and a
+// minimum delay between requests to the same host. Links come from public
+// submissions, so only public addresses are connected to.
+package linkcheck
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const maxRedirects = 10
+
+// ErrNotPublic is returned for links resolving to private, loopback and
+// link-local addresses, so the checker cannot be used to probe internal hosts
+var ErrNotPublic = errors.New("address is not public")
+
+// sharedAddressSpace is used for carrier-grade NAT and by some cloud networks
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Checker checks links. The zero value is not usable, use New.
+type Checker struct {
+	client       *http.Client
+	concurrency  int
+	hostInterval time.Duration
+	userAgent    string
+	// allowPrivate lets tests check links on httptest servers
+	allowPrivate bool
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// New returns a checker running at most concurrency requests at once and
+// waiting hostInterval between two requests to the same host
+func New(concurrency int, hostInterval time.Duration, timeout time.Duration) *Checker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	c := &Checker{
+		concurrency:  concurrency,
+		hostInterval: hostInterval,
+		userAgent:    "go-germany-linkcheck/1.0",
+		next:         make(map[string]time.Time),
+	}
+
+	// the address is checked once resolved, for every redirect, and without
+	// a proxy that would connect on the checker's behalf
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: c.control}
+	c.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+
+	return c
+}
+
+func (c *Checker) control(network, address string, _ syscall.RawConn) error {
+	if c.allowPrivate {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrNotPublic, addrPort.Addr())
+	}
+	return nil
+}
+
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// CheckAll checks every url and calls fn with each result. fn runs on the
+// calling goroutine, so it needs no locking.
+func (c *Checker) CheckAll(ctx context.Context, urls []string, fn func(models.LinkStatus)) {
+	jobs := make(chan string)
+	results := make(chan models.LinkStatus)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				results <- c.Check(ctx, u)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, u := range urls {
+			select {
+			case jobs <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for r := range results {
+		if ctx.Err() == nil {
+			fn(r)
+		}
+	}
+}
+
+// Check requests one url, following and recording redirects
+func (c *Checker) Check(ctx context.Context, link string) models.LinkStatus {
+	ls := models.LinkStatus{URL: link, Redirects: []string{}}
+
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		ls.Error = "invalid url"
+		ls.Broken = true
+		ls.CheckedAt = time.Now()
+		return ls
+	}
+
+	// some servers reject HEAD, retry those with GET
+	res, err := c.do(ctx, http.MethodHead, link, &ls)
+	if err == nil && (res.StatusCode == http.StatusMethodNotAllowed || res.StatusCode == http.StatusNotImplemented || res.StatusCode == http.StatusForbidden) {
+		ls.Redirects = ls.Redirects[:0]
+		res, err = c.do(ctx, http.MethodGet, link, &ls)
+	}
+
+	ls.CheckedAt = time.Now()
+	if err != nil {
+		ls.Error = err.Error()
+		ls.Broken = true
+		return ls
+	}
+
+	ls.Status = res.StatusCode
+	ls.FinalURL = res.Request.URL.String()
+	ls.Broken = ls.Status >= 400
+	return ls
+}
+
+func (c *Checker) do(ctx context.Context, method, link string, ls *models.LinkStatus) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	err = c.wait(ctx, req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	client := *c.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New("too many redirects")
+		}
+		ls.Redirects = append(ls.Redirects, req.URL.String())
+		return c.wait(req.Context(), req.URL.Host)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	res.Body.Close()
+
+	return res, nil
+}
+
+// wait blocks until host may be requested again
+func (c *Checker) wait(ctx context.Context, host string) error {
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	c.next[host] = at.Add(c.hostInterval)
+	c.mu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package linkcheck
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestChecker returns a checker allowed to reach httptest servers
+func newTestChecker(concurrency int, hostInterval, timeout time.Duration) *Checker {
+	c := New(concurrency, hostInterval, timeout)
+	c.allowPrivate = true
+	return c
+}
+
+func TestHeadFallsBackToGet(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	ls := newTestChecker(1, 0, time.Second).Check(context.Background(), srv.URL)
+
+	if ls.Broken || ls.Status != http.StatusOK {
+		t.Errorf("got status %d broken %v, want 200", ls.Status, ls.Broken)
+	}
+	if strings.Join(methods, ",") != "HEAD,GET" {
+		t.Errorf("got methods %v, want HEAD then GET", methods)
+	}
+}
+
+func TestRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+		case "/moved":
+			http.Redirect(w, r, "/new", http.StatusFound)
+		case "/new":
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestChecker(1, 0, time.Second)
+
+	ls := c.Check(context.Background(), srv.URL+"/old")
+	if ls.Broken || ls.Status != http.StatusOK || ls.FinalURL != srv.URL+"/new" {
+		t.Errorf("got %+v, want 200 at /new", ls)
+	}
+	if len(ls.Redirects) != 2 || ls.Redirects[0] != srv.URL+"/moved" || ls.Redirects[1] != srv.URL+"/new" {
+		t.Errorf("got redirects %v, want /moved and /new", ls.Redirects)
+	}
+
+	ls = c.Check(context.Background(), srv.URL+"/loop")
+	if !ls.Broken || !strings.Contains(ls.Error, "too many redirects") {
+		t.Errorf("got %+v, want too many redirects", ls)
+	}
+
+	ls = c.Check(context.Background(), srv.URL+"/gone")
+	if !ls.Broken || ls.Status != http.StatusNotFound {
+		t.Errorf("got %+v, want a broken 404", ls)
+	}
+}
+
+func TestHostInterval(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	interval := 50 * time.Millisecond
+	urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c", srv.URL + "/d"}
+
+	var results []models.LinkStatus
+	newTestChecker(4, interval, time.Second).CheckAll(context.Background(), urls, func(ls models.LinkStatus) {
+		results = append(results, ls)
+	})
+
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(results), len(urls))
+	}
+	for i := 1; i < len(times); i++ {
+		// timers may fire a little early on some platforms
+		if d := times[i].Sub(times[i-1]); d < interval-5*time.Millisecond {
+			t.Errorf("requests %d and %d were %s apart, want at least %s", i-1, i, d, interval)
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	start := time.Now()
+	ls := newTestChecker(1, 0, 100*time.Millisecond).Check(context.Background(), srv.URL)
+
+	if !ls.Broken || ls.Error == "" {
+		t.Errorf("got %+v, want a broken link with an error", ls)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("check took %s, want about the timeout", d)
+	}
+}
+
+func TestRefusesPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the checker connected to a loopback address")
+	}))
+	defer srv.Close()
+
+	c := New(1, 0, time.Second)
+	for _, link := range []string{srv.URL, "http://169.254.169.254/latest/meta-data/", "http://[::1]:1/", "http://10.0.0.1:1/"} {
+		ls := c.Check(context.Background(), link)
+		if !ls.Broken || !strings.Contains(ls.Error, ErrNotPublic.Error()) {
+			t.Errorf("%s: got %+v, want refused", link, ls)
+		}
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":        true,
+		"2606:4700::1111":      true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:93.184.216.34": true,
+	}
+	for addr, want := range tests {
+		if got := isPublic(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublic(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestControlWrapsErrNotPublic(t *testing.T) {
+	err := New(1, 0, time.Second).control("tcp", "127.0.0.1:80", nil)
+	if !errors.Is(err, ErrNotPublic) {
+		t.Errorf("got %v, want ErrNotPublic", err)
+	}
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const apiKeyColumns = `id, name, owner_email, prefix, rate_per_minute, burst, daily_quota, status, created_at, created_by, last_used_at`
+
+// InsertAPIKey stores a new active key and returns it with its plaintext,
+// which is not kept and cannot be shown again
+func (m *DBModel) InsertAPIKey(k APIKey) (*APIKey, string, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	token, _, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	key := "gg_" + token
+
+	stmt := `insert into api_key (name, owner_email, prefix, hash, rate_per_minute, burst, daily_quota, status, created_at, created_by)
+	values ($1, $2, $3, $4, $5, $6, $7, 'active', $8, $9) returning ` + apiKeyColumns
+
+	created, err := scanAPIKey(m.DB.QueryRowContext(ctx, stmt,
+		k.Name, k.OwnerEmail, key[:8], hashToken(key), k.RatePerMinute, k.Burst, k.DailyQuota, time.Now(), k.CreatedBy,
+	))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return created, key, nil
+}
+
+// GetAPIKeyByKey returns the key with the given plaintext
+func (m *DBModel) GetAPIKeyByKey(key string) (*APIKey, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, `select `+apiKeyColumns+` from api_key where hash = $1`, hashToken(key))
+
+	return scanAPIKey(row)
+}
+
+// GetAPIKeys returns every key
+func (m *DBModel) GetAPIKeys() ([]*APIKey, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select `+apiKeyColumns+` from api_key order by id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, rows.Err()
+}
+
+// GetAPIKey returns one key with its usage per endpoint over the last days
+func (m *DBModel) GetAPIKey(id int, days int) (*APIKey, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	k, err := scanAPIKey(m.DB.QueryRowContext(ctx, `select `+apiKeyColumns+` from api_key where id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.DB.QueryContext(ctx, `select to_char(day, 'YYYY-MM-DD'), endpoint, count from api_key_usage
+		where api_key_id = $1 and day > current_date - $2::integer order by day desc, endpoint`, id, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	k.Usage = []APIKeyUsage{}
+	for rows.Next() {
+		var u APIKeyUsage
+		err := rows.Scan(&u.Day, &u.Endpoint, &u.Count)
+		if err != nil {
+			return nil, err
+		}
+		k.Usage = append(k.Usage, u)
+	}
+
+	return k, rows.Err()
+}
+
+// SetAPIKeyStatus activates or suspends a key
+func (m *DBModel) SetAPIKeyStatus(id int, status string) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	res, err := m.DB.ExecContext(ctx, `update api_key set status = $2 where id = $1`, id, status)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CountAPIKeyRequest adds one request to the usage of a key and returns the
+// number of requests the key made today across all endpoints
+func (m *DBModel) CountAPIKeyRequest(id int, endpoint string) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `insert into api_key_usage (api_key_id, day, endpoint, count) values ($1, current_date, $2, 1)
+		on conflict (api_key_id, day, endpoint) do update set count = api_key_usage.count + 1`, id, endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `update api_key set last_used_at = $2 where id = $1`, id, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	var today int
+	err = tx.QueryRowContext(ctx, `select coalesce(sum(count), 0) from api_key_usage where api_key_id = $1 and day = current_date`, id).Scan(&today)
+	if err != nil {
+		return 0, err
+	}
+
+	return today, tx.Commit()
+}
+
+func scanAPIKey(row scanner) (*APIKey, error) {
+	var k APIKey
+	err := row.Scan(
+		&k.ID,
+		&k.Name,
+		&k.OwnerEmail,
+		&k.Prefix,
+		&k.RatePerMinute,
+		&k.Burst,
+		&k.DailyQuota,
+		&k.Status,
+		&k.CreatedAt,
+		&k.CreatedBy,
+		&k.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
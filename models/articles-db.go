@@ -10,7 +10,7 @@ import (
 
 // GetOneArticle returns one course and error, if any
 func (m *DBModel) GetOneArticle(id int) (*Article, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	query := `select c.id, c.link, c.title, c.author, c.published_date, c.source, 
@@ -117,16 +117,92 @@ func (article *Article) SetCourses(m *DBModel, ctx context.Context) error {
 }
 
 func (m *DBModel) GetArticles(ap ArticleParams) ([]*Article, int, error) {
-	// ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	// ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	var rows *sql.Rows
 	var err error
 
-	var query string
 	count := 0
 
+	orderBy := "order by c.published_date desc"
+	limitNOffset := fmt.Sprintf("limit %d offset %d", ap.PageSize, (ap.PageNumber-1)*ap.PageSize)
+
+	query := articleQuery(ap)
+
+	countQuery := fmt.Sprintf("select count(*) from (%s) as c", query)
+
+	row := m.DB.QueryRowContext(ctx, countQuery)
+	err = row.Scan(&count)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	//query with limit and offset
+	query = fmt.Sprintf("%s %s %s", query, orderBy, limitNOffset)
+
+	rows, err = m.DB.QueryContext(ctx, query)
+
+	if err != nil {
+		return nil, -1, err
+	}
+	defer rows.Close()
+
+	var articles []*Article
+	for rows.Next() {
+		article, err := ScanArticles(rows)
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if !ap.HideApplication {
+			// get the courses
+			err = article.SetCourses(m, ctx)
+			if err != nil {
+				return nil, -1, err
+			}
+		}
+
+		articles = append(articles, &article)
+	}
+
+	return articles, count, nil
+}
+
+// EachArticle calls fn for every article matching the filters, ignoring paging.
+// The linked courses are not loaded.
+func (m *DBModel) EachArticle(ap ArticleParams, fn func(*Article) error) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := fmt.Sprintf("%s order by c.published_date desc", articleQuery(ap))
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		article, err := ScanArticles(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&article)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// articleQuery builds the filtered article query without ordering and paging
+func articleQuery(ap ArticleParams) string {
+	var query string
+
 	baseQueryString := `select c.id, c.link, c.title, c.author, c.published_date, c.source, 
 	c.author_bs_school, c.author_bs_school_short, c.author_bs_department, c.author_bs_gpa,
 	c.author_ms_school, c.author_ms_school_short, c.author_ms_department, c.author_ms_gpa,
@@ -153,9 +229,6 @@ func (m *DBModel) GetArticles(ap ArticleParams) ([]*Article, int, error) {
 	inMsDepartments := ""
 	equalCourseType := ""
 
-	orderBy := "order by c.published_date desc"
-	limitNOffset := fmt.Sprintf("limit %d offset %d", ap.PageSize, (ap.PageNumber-1)*ap.PageSize)
-
 	var whereArr []string
 	where := ""
 
@@ -205,43 +278,7 @@ func (m *DBModel) GetArticles(ap ArticleParams) ([]*Article, int, error) {
 		query = baseQueryString
 	}
 
-	countQuery := fmt.Sprintf("select count(*) from (%s) as c", query)
-
-	row := m.DB.QueryRowContext(ctx, countQuery)
-	err = row.Scan(&count)
-	if err != nil {
-		return nil, -1, err
-	}
-
-	//query with limit and offset
-	query = fmt.Sprintf("%s %s %s", query, orderBy, limitNOffset)
-
-	rows, err = m.DB.QueryContext(ctx, query)
-
-	if err != nil {
-		return nil, -1, err
-	}
-	defer rows.Close()
-
-	var articles []*Article
-	for rows.Next() {
-		article, err := ScanArticles(rows)
-		if err != nil {
-			return nil, -1, err
-		}
-
-		if !ap.HideApplication {
-			// get the courses
-			err = article.SetCourses(m, ctx)
-			if err != nil {
-				return nil, -1, err
-			}
-		}
-
-		articles = append(articles, &article)
-	}
-
-	return articles, count, nil
+	return query
 }
 
 func ScanArticles(rows *sql.Rows) (Article, error) {
@@ -274,7 +311,7 @@ func ScanArticles(rows *sql.Rows) (Article, error) {
 }
 
 func (m *DBModel) GetArticleFilters() (*ArticleFilters, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	var articleFilters ArticleFilters
@@ -424,12 +461,16 @@ func (m *DBModel) GetArticleFilters() (*ArticleFilters, error) {
 }
 
 func (m *DBModel) InsertArticle(ca CourseArticle) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
+	return insertArticle(ctx, m.DB, ca)
+}
+
+func insertArticle(ctx context.Context, db execer, ca CourseArticle) error {
 	stmt := `insert into article (id, course_id, result, is_decision) values ($1, $2, $3, $4)`
 
-	_, err := m.DB.ExecContext(ctx, stmt,
+	_, err := db.ExecContext(ctx, stmt,
 		ca.ArticleID,
 		ca.CourseID,
 		ca.Result,
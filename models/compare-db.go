@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CourseNotFoundError is returned by Compare for an id without a course
+type CourseNotFoundError struct {
+	ID int
+}
+
+func (e *CourseNotFoundError) Error() string {
+	return fmt.Sprintf("course %d not found", e.ID)
+}
+
+// Compare returns the courses with the given ids and a normalized comparison matrix
+func (m *DBModel) Compare(ids []int) (*CourseComparison, error) {
+	var comparison CourseComparison
+
+	for _, id := range ids {
+		course, err := m.Get(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &CourseNotFoundError{ID: id}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("course %d: %w", id, err)
+		}
+		comparison.Courses = append(comparison.Courses, course)
+	}
+
+	attributes := []struct {
+		name  string
+		value func(c *Course) interface{}
+	}{
+		{"university", func(c *Course) interface{} { return c.UniversityNameEn }},
+		{"city", func(c *Course) interface{} { return c.City }},
+		{"course_type", func(c *Course) interface{} { return c.CourseType }},
+		{"subject", func(c *Course) interface{} { return c.Subject }},
+		{"tuition_fees", func(c *Course) interface{} { return strings.TrimSpace(c.TuitionFees) }},
+		{"programme_duration", func(c *Course) interface{} { return strings.TrimSpace(c.ProgrammeDuration) }},
+		{"beginning", func(c *Course) interface{} { return strings.TrimSpace(c.Beginning) }},
+		{"application_deadline", func(c *Course) interface{} { return strings.TrimSpace(c.ApplicationDeadline) }},
+		{"languages", func(c *Course) interface{} { return sortedLanguages(c.CourseLanguage) }},
+		{"qs_ranking", func(c *Course) interface{} { return c.QsRanking }},
+		{"is_tu9", func(c *Course) interface{} { return c.IsTu9 }},
+		{"is_u15", func(c *Course) interface{} { return c.IsU15 }},
+		{"is_elearning", func(c *Course) interface{} { return c.IsElearning }},
+		{"is_complete_online_possible", func(c *Course) interface{} { return c.IsCompleteOnlinePossible }},
+	}
+
+	for _, a := range attributes {
+		row := ComparedAttribute{Name: a.name}
+		for i, c := range comparison.Courses {
+			v := a.value(c)
+			if i > 0 && fmt.Sprint(v) != fmt.Sprint(row.Values[0]) {
+				row.Differs = true
+			}
+			row.Values = append(row.Values, v)
+		}
+		comparison.Attributes = append(comparison.Attributes, row)
+	}
+
+	for _, c := range comparison.Courses {
+		comparison.Statistics = append(comparison.Statistics, summarizeAdmissions(c))
+	}
+
+	return &comparison, nil
+}
+
+func sortedLanguages(languages []string) []string {
+	sorted := make([]string, len(languages))
+	copy(sorted, languages)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func summarizeAdmissions(c *Course) CourseAdmissionSummary {
+	s := CourseAdmissionSummary{
+		CourseID:     c.ID,
+		ArticleCount: len(c.CourseArticle),
+	}
+
+	for _, a := range c.CourseArticle {
+		switch a.Result {
+		case "Admission":
+			s.Admissions++
+		case "Rejection":
+			s.Rejections++
+		}
+		if a.IsDecision {
+			s.Decisions++
+		}
+	}
+
+	if s.Admissions+s.Rejections > 0 {
+		s.AdmissionRate = float64(s.Admissions) / float64(s.Admissions+s.Rejections)
+	}
+
+	return s
+}
@@ -2,20 +2,30 @@ package models
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 func (m *DBModel) InsertContent(content Content) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
 	defer cancel()
 
+	return insertContent(ctx, m.DB, content)
+}
+
+func insertContent(ctx context.Context, db execer, content Content) error {
 	stmt := `insert into content (id, link, title, author, published_date, source, 
 		author_bs_school, author_bs_school_short, author_bs_department, author_bs_gpa,
 		author_ms_school, author_ms_school_short, author_ms_department, author_ms_gpa,
 		author_toefl, author_ielts, author_gre, author_gmat, author_testdaf, author_goethe, course_type, content)
 		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
 
-	_, err := m.DB.ExecContext(ctx, stmt,
+	_, err := db.ExecContext(ctx, stmt,
 		content.ID,
 		content.Link,
 		content.Title,
@@ -45,3 +55,42 @@ func (m *DBModel) InsertContent(content Content) error {
 	}
 	return nil
 }
+
+// ContentLinks returns the link of every content row keyed by id
+func (m *DBModel) ContentLinks() (map[int]string, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select id, link from content`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var link string
+		err := rows.Scan(&id, &link)
+		if err != nil {
+			return nil, err
+		}
+		links[id] = link
+	}
+
+	return links, rows.Err()
+}
+
+// GetContentBody returns the article text of one content row
+func (m *DBModel) GetContentBody(id int) (string, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	var body string
+	err := m.DB.QueryRowContext(ctx, `select content from content where id = $1`, id).Scan(&body)
+	if err != nil {
+		return "", err
+	}
+
+	return body, nil
+}
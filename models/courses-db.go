@@ -10,12 +10,26 @@ import (
 )
 
 type DBModel struct {
-	DB *sql.DB
+	DB  *sql.DB
+	ctx context.Context
+}
+
+// WithContext returns a copy of m whose queries carry the values of ctx, like
+// the trace of the request, but not its cancellation
+func (m *DBModel) WithContext(ctx context.Context) *DBModel {
+	return &DBModel{DB: m.DB, ctx: context.WithoutCancel(ctx)}
+}
+
+func (m *DBModel) baseContext() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
 }
 
 // Get returns one course and error, if any
 func (m *DBModel) Get(id int) (*Course, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	query := `select c.id, c.university_id, c.course_type, c.name_en, c.name_en_short, c.tuition_fees, c.beginning, c.subject, c.daadlink, c.is_elearning, c.application_deadline,
@@ -49,7 +63,7 @@ func (m *DBModel) Get(id int) (*Course, error) {
 
 // Count return length of courses
 func (m *DBModel) Count() (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	query := `select count(*) from course`
@@ -66,7 +80,7 @@ func (m *DBModel) Count() (int, error) {
 
 // Filters return filter object
 func (m *DBModel) GetFilters() (*Filters, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	var filters Filters
@@ -169,14 +183,103 @@ func (m *DBModel) GetFilters() (*Filters, error) {
 // All return all courses and error, if any
 // func (m *DBModel) All(pageNumber int, pageSize int) ([]*Course, error) {
 func (m *DBModel) All(cp CourseParams) ([]*Course, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
 	defer cancel()
 
 	var rows *sql.Rows
 	var err error
 
-	var query string
 	count := 0
+	orderBy := "order by u.name_en, c.course_type, c.name_en"
+	limitNOffset := fmt.Sprintf("limit %d offset %d", cp.PageSize, (cp.PageNumber-1)*cp.PageSize)
+
+	query := courseQuery(cp)
+
+	//original query to count total rows
+	countQuery := fmt.Sprintf("select count(*) from (%s) as c", query)
+
+	row := m.DB.QueryRowContext(ctx, countQuery)
+	err = row.Scan(&count)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	//query with limit and offset
+	query = fmt.Sprintf("%s %s %s", query, orderBy, limitNOffset)
+
+	rows, err = m.DB.QueryContext(ctx, query)
+
+	if err != nil {
+		return nil, -1, err
+	}
+	defer rows.Close()
+
+	var courses []*Course
+	for rows.Next() {
+		course, err := ScanCourses(rows)
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if !cp.HideLanguageNArticle {
+			// get the languages
+			err = course.SetLanguages(m, ctx)
+			if err != nil {
+				return nil, -1, err
+			}
+
+			// get the articles
+			err = course.SetArticles(m, ctx)
+			if err != nil {
+				return nil, -1, err
+			}
+		}
+
+		courses = append(courses, &course)
+	}
+	// log.Println(count)
+
+	return courses, count, nil
+
+}
+
+// EachCourse calls fn for every course matching the filters, ignoring paging.
+// Languages are taken from the aggregated column and articles are not loaded.
+func (m *DBModel) EachCourse(cp CourseParams, fn func(*Course) error) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := fmt.Sprintf("%s order by u.name_en, c.course_type, c.name_en", courseQuery(cp))
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		course, err := ScanCourses(rows)
+		if err != nil {
+			return err
+		}
+
+		if course.Languages != "" {
+			course.CourseLanguage = strings.Split(course.Languages, ",")
+		}
+
+		err = fn(&course)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// courseQuery builds the filtered course query without ordering and paging
+func courseQuery(cp CourseParams) string {
+	var query string
+
 	// baseQueryString := `select c.id, c.university_id, c.course_type, c.name_en, c.name_en_short, c.tuition_fees, c.beginning, c.subject, c.daadlink, c.is_elearning, c.application_deadline,
 	// c.is_complete_online_possible, c.programme_duration, c.is_from_daad, c.created_at, COALESCE(c.updated_at, c.created_at),
 	// u.name_en, u.name_ch, u.city, u.is_tu9, u.is_u15, COALESCE(u.qs_ranking, 0), u.link
@@ -218,8 +321,6 @@ func (m *DBModel) All(cp CourseParams) ([]*Course, int, error) {
 	groupBy := `group by c.id, c.university_id, c.course_type, c.name_en, c.name_en_short, c.tuition_fees, c.beginning, c.subject, c.daadlink, c.is_elearning, c.application_deadline,
 	c.is_complete_online_possible, c.programme_duration, c.is_from_daad, c.created_at, COALESCE(c.updated_at, c.created_at),
 	u.name_en, u.name_ch, u.city, u.is_tu9, u.is_u15, COALESCE(u.qs_ranking, 0), u.link, a.course_id`
-	orderBy := "order by u.name_en, c.course_type, c.name_en"
-	limitNOffset := fmt.Sprintf("limit %d offset %d", cp.PageSize, (cp.PageNumber-1)*cp.PageSize)
 
 	// where likeSearchTerm inCourse inUniversity inSubject isTu9 isU15
 	// having havingLngs hasArticles
@@ -288,55 +389,10 @@ func (m *DBModel) All(cp CourseParams) ([]*Course, int, error) {
 		query = fmt.Sprintf("%s %s", baseQueryString, groupBy)
 	}
 
-	//original query to count total rows
-	countQuery := fmt.Sprintf("select count(*) from (%s) as c", query)
-
-	row := m.DB.QueryRowContext(ctx, countQuery)
-	err = row.Scan(&count)
-	if err != nil {
-		return nil, -1, err
-	}
-
-	//query with limit and offset
-	query = fmt.Sprintf("%s %s %s", query, orderBy, limitNOffset)
-
-	rows, err = m.DB.QueryContext(ctx, query)
-
-	if err != nil {
-		return nil, -1, err
-	}
-	defer rows.Close()
-
-	var courses []*Course
-	for rows.Next() {
-		course, err := ScanCourses(rows)
-		if err != nil {
-			return nil, -1, err
-		}
-
-		if !cp.HideLanguageNArticle {
-			// get the languages
-			err = course.SetLanguages(m, ctx)
-			if err != nil {
-				return nil, -1, err
-			}
-
-			// get the articles
-			err = course.SetArticles(m, ctx)
-			if err != nil {
-				return nil, -1, err
-			}
-		}
-
-		courses = append(courses, &course)
-	}
-	// log.Println(count)
-
-	return courses, count, nil
-
+	return query
 }
 
-func ScanCourse(row *sql.Row) (Course, error) {
+func ScanCourse(row scanner) (Course, error) {
 	var course Course
 	err := row.Scan(
 		&course.ID,
@@ -484,7 +540,7 @@ func (course *Course) SetArticles(m *DBModel, ctx context.Context) error {
 }
 
 func (m *DBModel) InsertCourse(course Course) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
 	defer cancel()
 
 	stmt := `insert into course (id, university_id, course_type, name_en, name_en_short, 
@@ -519,3 +575,118 @@ func (m *DBModel) InsertCourse(course Course) error {
 	}
 	return nil
 }
+
+// CourseIndex returns the id, university, type and english name of every course
+func (m *DBModel) CourseIndex() ([]Course, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select id, university_id, course_type, name_en from course order by id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []Course
+	for rows.Next() {
+		var c Course
+		err := rows.Scan(&c.ID, &c.UniversityId, &c.CourseType, &c.NameEn)
+		if err != nil {
+			return nil, err
+		}
+		courses = append(courses, c)
+	}
+
+	return courses, rows.Err()
+}
+
+// ImportCourses inserts and updates courses in a single transaction
+func (m *DBModel) ImportCourses(inserts, updates []Course) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insertStmt := `insert into course (id, university_id, course_type, name_en, name_en_short, 
+		name_ch, name_ch_short, tuition_fees, beginning, subject, daadlink, is_elearning, application_deadline, is_complete_online_possible,
+		programme_duration, is_from_daad, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+
+	for _, c := range inserts {
+		uid, _ := strconv.Atoi(c.UniversityId)
+		ct, _ := strconv.Atoi(c.CourseType)
+
+		_, err = tx.ExecContext(ctx, insertStmt,
+			c.ID, uid, ct, c.NameEn, c.NameEnShort, c.NameCh, c.NameChShort, c.TuitionFees, c.Beginning, c.Subject,
+			c.Daadlink, c.IsElearning, c.ApplicationDeadline, c.IsCompleteOnlinePossible, c.ProgrammeDuration, c.IsFromDaad,
+			c.CreatedAt, nil,
+		)
+		if err != nil {
+			return fmt.Errorf("course %d: %w", c.ID, err)
+		}
+	}
+
+	updateStmt := `update course set university_id = $2, course_type = $3, name_en = $4, name_en_short = $5,
+		name_ch = $6, name_ch_short = $7, tuition_fees = $8, beginning = $9, subject = $10, daadlink = $11, is_elearning = $12,
+		application_deadline = $13, is_complete_online_possible = $14, programme_duration = $15, is_from_daad = $16, updated_at = $17
+		where id = $1`
+
+	for _, c := range updates {
+		uid, _ := strconv.Atoi(c.UniversityId)
+		ct, _ := strconv.Atoi(c.CourseType)
+
+		_, err = tx.ExecContext(ctx, updateStmt,
+			c.ID, uid, ct, c.NameEn, c.NameEnShort, c.NameCh, c.NameChShort, c.TuitionFees, c.Beginning, c.Subject,
+			c.Daadlink, c.IsElearning, c.ApplicationDeadline, c.IsCompleteOnlinePossible, c.ProgrammeDuration, c.IsFromDaad,
+			time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("course %d: %w", c.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CourseNames returns the names of every course with its university names
+func (m *DBModel) CourseNames() ([]Course, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select c.id, c.university_id, c.course_type, c.name_en, c.name_en_short, COALESCE(c.name_ch, ''), COALESCE(c.name_ch_short, ''),
+	COALESCE(u.name_en, ''), COALESCE(u.name_ch, '')
+	from course as c
+	left join university as u on c.university_id = u.id
+	order by c.id`
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []Course
+	for rows.Next() {
+		var c Course
+		err := rows.Scan(
+			&c.ID,
+			&c.UniversityId,
+			&c.CourseType,
+			&c.NameEn,
+			&c.NameEnShort,
+			&c.NameCh,
+			&c.NameChShort,
+			&c.UniversityNameEn,
+			&c.UniversityNameCh,
+		)
+		if err != nil {
+			return nil, err
+		}
+		courses = append(courses, c)
+	}
+
+	return courses, rows.Err()
+}
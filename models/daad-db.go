@@ -0,0 +1,363 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// daadColumns are the course columns a DAAD sync may change
+var daadColumns = map[string]bool{
+	"name_en":                     true,
+	"name_en_short":               true,
+	"subject":                     true,
+	"tuition_fees":                true,
+	"beginning":                   true,
+	"programme_duration":          true,
+	"application_deadline":        true,
+	"is_elearning":                true,
+	"is_complete_online_possible": true,
+	"daadlink":                    true,
+}
+
+// DaadCourses returns every course that came from DAAD or links to it
+func (m *DBModel) DaadCourses() ([]Course, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select id, university_id, course_type, name_en, name_en_short, tuition_fees, beginning, subject, daadlink,
+	is_elearning, application_deadline, is_complete_online_possible, programme_duration, is_from_daad
+	from course
+	where is_from_daad or daadlink <> ''
+	order by id`
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []Course
+	for rows.Next() {
+		var c Course
+		err := rows.Scan(
+			&c.ID,
+			&c.UniversityId,
+			&c.CourseType,
+			&c.NameEn,
+			&c.NameEnShort,
+			&c.TuitionFees,
+			&c.Beginning,
+			&c.Subject,
+			&c.Daadlink,
+			&c.IsElearning,
+			&c.ApplicationDeadline,
+			&c.IsCompleteOnlinePossible,
+			&c.ProgrammeDuration,
+			&c.IsFromDaad,
+		)
+		if err != nil {
+			return nil, err
+		}
+		courses = append(courses, c)
+	}
+
+	return courses, rows.Err()
+}
+
+// InsertDaadSync stores a computed diff for review and returns its id
+func (m *DBModel) InsertDaadSync(snapshot string, diff DaadDiff) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	js, err := json.Marshal(diff)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = m.DB.QueryRowContext(ctx,
+		`insert into daad_sync (snapshot, status, diff, created_at) values ($1, 'pending', $2, $3) returning id`,
+		snapshot, js, time.Now(),
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetDaadSyncs returns all syncs, newest first, without their diffs
+func (m *DBModel) GetDaadSyncs() ([]*DaadSync, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select id, snapshot, status, created_at, reviewed_at, reviewed_by from daad_sync order by id desc`
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var syncs []*DaadSync
+	for rows.Next() {
+		var s DaadSync
+		err := rows.Scan(&s.ID, &s.Snapshot, &s.Status, &s.CreatedAt, &s.ReviewedAt, &s.ReviewedBy)
+		if err != nil {
+			return nil, err
+		}
+		syncs = append(syncs, &s)
+	}
+
+	return syncs, rows.Err()
+}
+
+// GetDaadSync returns one sync with its diff and the changes it applied
+func (m *DBModel) GetDaadSync(id int) (*DaadSync, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	var s DaadSync
+	var diff []byte
+
+	query := `select id, snapshot, status, diff, created_at, reviewed_at, reviewed_by from daad_sync where id = $1`
+
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(&s.ID, &s.Snapshot, &s.Status, &diff, &s.CreatedAt, &s.ReviewedAt, &s.ReviewedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(diff, &s.Diff)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `select id, sync_id, daad_id, course_id, change, field, old_value, new_value, changed_at
+	from daad_sync_change where sync_id = $1 order by id`
+
+	rows, err := m.DB.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c DaadChange
+		err := rows.Scan(&c.ID, &c.SyncID, &c.DaadID, &c.CourseID, &c.Change, &c.Field, &c.OldValue, &c.NewValue, &c.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		s.Changes = append(s.Changes, c)
+	}
+
+	return &s, rows.Err()
+}
+
+// ApplyDaadSync writes a pending diff to the course table in a single transaction,
+// recording every field it changes. Fields edited since the diff was computed,
+// and new programmes whose link was added meanwhile, are skipped and recorded
+// as conflicts. Vanished programmes are recorded but kept.
+func (m *DBModel) ApplyDaadSync(id int, reviewer int) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	diff, err := lockPendingDaadSync(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	logStmt := `insert into daad_sync_change (sync_id, daad_id, course_id, change, field, old_value, new_value, changed_at)
+	values ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	var nextID int
+	err = tx.QueryRowContext(ctx, `select coalesce(max(id), 0) from course`).Scan(&nextID)
+	if err != nil {
+		return err
+	}
+
+	insertStmt := `insert into course (id, university_id, course_type, name_en, name_en_short, 
+		name_ch, name_ch_short, tuition_fees, beginning, subject, daadlink, is_elearning, application_deadline, is_complete_online_possible,
+		programme_duration, is_from_daad, created_at, updated_at) values ($1, $2, $3, $4, $5, '', '', $6, $7, $8, $9, $10, $11, $12, $13, true, $14, null)`
+
+	var nextLanguageID int
+	err = tx.QueryRowContext(ctx, `select coalesce(max(id), 0) from courses_languages`).Scan(&nextLanguageID)
+	if err != nil {
+		return err
+	}
+
+	languageStmt := `insert into courses_languages (id, course_id, language_id)
+	select $1 + row_number() over (order by l.id), $2, l.id from language as l where lower(l.name) = any($3)
+	returning id`
+
+	for _, p := range diff.New {
+		ct, err := strconv.Atoi(p.CourseType)
+		if err != nil || ct <= 0 {
+			return fmt.Errorf("daad programme %d: unknown course type %q", p.DaadID, p.CourseType)
+		}
+
+		var exists bool
+		err = tx.QueryRowContext(ctx, `select exists (select 1 from course where daadlink = $1)`, p.Daadlink).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			_, err = tx.ExecContext(ctx, logStmt, id, p.DaadID, 0, "conflict", "daadlink", p.Daadlink, p.NameEn, now)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		nextID++
+		_, err = tx.ExecContext(ctx, insertStmt,
+			nextID, p.UniversityID, ct, p.NameEn, p.NameEnShort, p.TuitionFees, p.Beginning, p.Subject, p.Daadlink,
+			p.IsElearning != nil && *p.IsElearning, p.ApplicationDeadline,
+			p.IsCompleteOnlinePossible != nil && *p.IsCompleteOnlinePossible, p.ProgrammeDuration, now,
+		)
+		if err != nil {
+			return fmt.Errorf("daad programme %d: %w", p.DaadID, err)
+		}
+
+		languages := make([]string, len(p.Languages))
+		for i, l := range p.Languages {
+			languages[i] = strings.ToLower(strings.TrimSpace(l))
+		}
+		rows, err := tx.QueryContext(ctx, languageStmt, nextLanguageID, nextID, pq.Array(languages))
+		if err != nil {
+			return fmt.Errorf("daad programme %d: %w", p.DaadID, err)
+		}
+		for rows.Next() {
+			nextLanguageID++
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		// the course table has no city, fill in the university's when it is unknown
+		if p.City != "" {
+			_, err = tx.ExecContext(ctx, `update university set city = $2 where id = $1 and coalesce(city, '') = ''`, p.UniversityID, p.City)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, logStmt, id, p.DaadID, nextID, "new", "", "", p.NameEn, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range diff.Changed {
+		for _, fc := range c.Changes {
+			if !daadColumns[fc.Field] {
+				return fmt.Errorf("daad sync may not change %q", fc.Field)
+			}
+
+			var current string
+			query := fmt.Sprintf(`select coalesce(%s::text, '') from course where id = $1 for update`, fc.Field)
+			err = tx.QueryRowContext(ctx, query, c.CourseID).Scan(&current)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("course %d: %w", c.CourseID, err)
+			}
+			if err != nil || current != fc.Old {
+				_, err = tx.ExecContext(ctx, logStmt, id, c.DaadID, c.CourseID, "conflict", fc.Field, current, fc.New, now)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			var value interface{} = fc.New
+			if fc.Field == "is_elearning" || fc.Field == "is_complete_online_possible" {
+				value, _ = strconv.ParseBool(fc.New)
+			}
+
+			stmt := fmt.Sprintf(`update course set %s = $2, updated_at = $3 where id = $1`, fc.Field)
+			_, err = tx.ExecContext(ctx, stmt, c.CourseID, value, now)
+			if err != nil {
+				return fmt.Errorf("course %d: %w", c.CourseID, err)
+			}
+
+			_, err = tx.ExecContext(ctx, logStmt, id, c.DaadID, c.CourseID, "changed", fc.Field, fc.Old, fc.New, now)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range diff.Vanished {
+		_, err = tx.ExecContext(ctx, logStmt, id, v.DaadID, v.CourseID, "vanished", "", v.NameEn, "", now)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `update daad_sync set status = 'applied', reviewed_at = $2, reviewed_by = $3 where id = $1`, id, now, reviewer)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RejectDaadSync marks a pending diff as rejected without touching courses
+func (m *DBModel) RejectDaadSync(id int, reviewer int) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = lockPendingDaadSync(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `update daad_sync set status = 'rejected', reviewed_at = $2, reviewed_by = $3 where id = $1`, id, time.Now(), reviewer)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func lockPendingDaadSync(ctx context.Context, tx *sql.Tx, id int) (*DaadDiff, error) {
+	var status string
+	var js []byte
+
+	err := tx.QueryRowContext(ctx, `select status, diff from daad_sync where id = $1 for update`, id).Scan(&status, &js)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != "pending" {
+		return nil, errors.New("daad sync is already " + status)
+	}
+
+	var diff DaadDiff
+	err = json.Unmarshal(js, &diff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diff, nil
+}
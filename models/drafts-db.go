@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateDraft is returned when a draft with the same canonical link exists
+var ErrDuplicateDraft = errors.New("a draft with this link already exists")
+
+const draftColumns = `id, source, link, canonical_link, title, author, published_at, content, status, content_id,
+	created_at, reviewed_at, reviewed_by`
+
+// InsertDraft queues an ingested article for review and returns its id
+func (m *DBModel) InsertDraft(d ContentDraft) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	stmt := `insert into content_draft (source, link, canonical_link, title, author, published_at, content, status, created_at)
+	values ($1, $2, $3, $4, $5, $6, $7, 'pending', $8) returning id`
+
+	var id int
+	err := m.DB.QueryRowContext(ctx, stmt,
+		d.Source, d.Link, d.CanonicalLink, d.Title, d.Author, d.PublishedAt, d.Content, time.Now(),
+	).Scan(&id)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return 0, ErrDuplicateDraft
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetDrafts returns the drafts with the given status, all drafts if status is empty
+func (m *DBModel) GetDrafts(status string) ([]*ContentDraft, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select ` + draftColumns + ` from content_draft where $1 = '' or status = $1 order by created_at desc`
+
+	rows, err := m.DB.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drafts []*ContentDraft
+	for rows.Next() {
+		d, err := scanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, d)
+	}
+
+	return drafts, rows.Err()
+}
+
+// GetDraft returns one draft
+func (m *DBModel) GetDraft(id int) (*ContentDraft, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, `select `+draftColumns+` from content_draft where id = $1`, id)
+
+	return scanDraft(row)
+}
+
+// ApproveDraft inserts content built from a pending draft and marks the draft
+// approved in one transaction. A zero content id takes the next free id.
+func (m *DBModel) ApproveDraft(id int, content Content, reviewer int) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	err = lockPendingDraft(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if content.ID == 0 {
+		err = tx.QueryRowContext(ctx, `select coalesce(max(id), 0) + 1 from content`).Scan(&content.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = insertContent(ctx, tx, content)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `update content_draft set status = 'approved', content_id = $2, reviewed_at = $3, reviewed_by = $4 where id = $1`,
+		id, content.ID, time.Now(), reviewer)
+	if err != nil {
+		return 0, err
+	}
+
+	return content.ID, tx.Commit()
+}
+
+// RejectDraft marks a pending draft rejected
+func (m *DBModel) RejectDraft(id int, reviewer int) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockPendingDraft(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `update content_draft set status = 'rejected', reviewed_at = $2, reviewed_by = $3 where id = $1`,
+		id, time.Now(), reviewer)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func lockPendingDraft(ctx context.Context, tx *sql.Tx, id int) error {
+	var status string
+
+	err := tx.QueryRowContext(ctx, `select status from content_draft where id = $1 for update`, id).Scan(&status)
+	if err != nil {
+		return err
+	}
+
+	if status != "pending" {
+		return errors.New("draft is already " + status)
+	}
+	return nil
+}
+
+func scanDraft(row scanner) (*ContentDraft, error) {
+	var d ContentDraft
+	err := row.Scan(
+		&d.ID,
+		&d.Source,
+		&d.Link,
+		&d.CanonicalLink,
+		&d.Title,
+		&d.Author,
+		&d.PublishedAt,
+		&d.Content,
+		&d.Status,
+		&d.ContentID,
+		&d.CreatedAt,
+		&d.ReviewedAt,
+		&d.ReviewedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"context"
+)
+
+// The health checks take the context of the probe, so they fail within its
+// timeout instead of the usual query timeout.
+
+// Ping checks that a connection to the database can be used
+func (m *DBModel) Ping(ctx context.Context) error {
+	return m.DB.PingContext(ctx)
+}
+
+// SchemaVersion returns the highest migration applied to the database
+func (m *DBModel) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := m.DB.QueryRowContext(ctx, `select coalesce(max(version), 0) from schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Query describes a statement that has run. Rows is the number of rows read
+// or affected.
+type Query struct {
+	Method   string
+	SQL      string
+	Start    time.Time
+	Duration time.Duration
+	Rows     int64
+	Err      error
+}
+
+// QueryHook is called once a statement has run, or once its rows are closed
+type QueryHook func(ctx context.Context, q Query)
+
+// NewConnector returns a postgres connector that reports every statement to
+// the hooks, named after the models method that ran it
+func NewConnector(dsn string, hooks ...QueryHook) (driver.Connector, error) {
+	c, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{Connector: c, hooks: hooks}, nil
+}
+
+type connector struct {
+	driver.Connector
+	hooks []QueryHook
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: cn, hooks: c.hooks}, nil
+}
+
+// conn forwards to the pq connection, which implements all of the context
+// aware driver interfaces
+type conn struct {
+	driver.Conn
+	hooks []QueryHook
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q := Query{Method: modelMethod(), SQL: query, Start: time.Now()}
+
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	if err != nil {
+		q.Err = err
+		c.report(ctx, q)
+		return nil, err
+	}
+	return &countingRows{Rows: rows, ctx: ctx, conn: c, query: q}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	q := Query{Method: modelMethod(), SQL: query, Start: time.Now()}
+
+	res, err := c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	if err != nil {
+		q.Err = err
+	} else {
+		q.Rows, _ = res.RowsAffected()
+	}
+	c.report(ctx, q)
+	return res, err
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *conn) report(ctx context.Context, q Query) {
+	q.Duration = time.Since(q.Start)
+	for _, hook := range c.hooks {
+		hook(ctx, q)
+	}
+}
+
+// countingRows reports the query when its rows are closed
+type countingRows struct {
+	driver.Rows
+	ctx   context.Context
+	conn  *conn
+	query Query
+}
+
+func (r *countingRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	switch err {
+	case nil:
+		r.query.Rows++
+	case io.EOF:
+	default:
+		r.query.Err = err
+	}
+	return err
+}
+
+func (r *countingRows) Close() error {
+	err := r.Rows.Close()
+	r.conn.report(r.ctx, r.query)
+	return err
+}
+
+// packagePrefix is the prefix of the function names of this package
+var packagePrefix = strings.TrimSuffix(runtime.FuncForPC(reflect.ValueOf((*DBModel).Get).Pointer()).Name(), "(*DBModel).Get")
+
+// modelMethod returns the name of the models method running the statement,
+// like All or SetLanguages
+func modelMethod() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, packagePrefix); ok {
+			// drop the receiver and the suffix of closures, as in (*DBModel).All.func1
+			if i := strings.Index(name, ")."); i >= 0 {
+				name = name[i+2:]
+			}
+			name, _, _ = strings.Cut(name, ".")
+			return name
+		}
+		if !more {
+			return "other"
+		}
+	}
+}
+
+// SanitizedSQL returns the statement with its string and number literals
+// replaced by ? and its whitespace collapsed, so filter values interpolated
+// into the query do not leak into traces
+func (q Query) SanitizedSQL() string {
+	var b strings.Builder
+	sql := q.SQL
+	space := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = b.Len() > 0
+			continue
+		case c == '\'':
+			// skip to the closing quote, '' is an escaped quote
+			for i++; i < len(sql); i++ {
+				if sql[i] == '\'' {
+					if i+1 < len(sql) && sql[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			c = '?'
+		case c >= '0' && c <= '9' && !identifierByte(sql, i-1):
+			for i+1 < len(sql) && (sql[i+1] >= '0' && sql[i+1] <= '9' || sql[i+1] == '.') {
+				i++
+			}
+			c = '?'
+		}
+
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// identifierByte reports whether sql[i] continues an identifier or a $n
+// placeholder, whose digits are kept
+func identifierByte(sql string, i int) bool {
+	if i < 0 {
+		return false
+	}
+	c := sql[i]
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const linkTargetsQuery = `select 'course', id, name_en, daadlink from course where daadlink <> ''
+	union all
+	select 'university', id, name_en, link from university where link <> ''
+	union all
+	select 'article', id, title, link from content where link <> ''`
+
+// LinkTargets returns every course, university and article with an external link
+func (m *DBModel) LinkTargets() ([]LinkTarget, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, linkTargetsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var targets []LinkTarget
+	for rows.Next() {
+		var t LinkTarget
+		err := rows.Scan(&t.Kind, &t.ID, &t.Name, &t.URL)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+
+	return targets, rows.Err()
+}
+
+// SaveLinkStatus stores the result of a link check, replacing the previous one
+func (m *DBModel) SaveLinkStatus(ls LinkStatus) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	redirects, err := json.Marshal(ls.Redirects)
+	if err != nil {
+		return err
+	}
+
+	stmt := `insert into link_check (url, status, final_url, redirects, error, checked_at) values ($1, $2, $3, $4, $5, $6)
+	on conflict (url) do update set status = excluded.status, final_url = excluded.final_url, redirects = excluded.redirects,
+	error = excluded.error, checked_at = excluded.checked_at`
+
+	_, err = m.DB.ExecContext(ctx, stmt, ls.URL, ls.Status, ls.FinalURL, redirects, ls.Error, ls.CheckedAt)
+	return err
+}
+
+// LinkStatuses returns the last check of each of the given urls that was checked
+func (m *DBModel) LinkStatuses(urls []string) (map[string]*LinkStatus, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select url, status, final_url, redirects, error, checked_at from link_check where url = any($1)`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(urls))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]*LinkStatus)
+	for rows.Next() {
+		ls, err := scanLinkStatus(rows)
+		if err != nil {
+			return nil, err
+		}
+		statuses[ls.URL] = ls
+	}
+
+	return statuses, rows.Err()
+}
+
+// BrokenLinks returns every link target whose last check failed
+func (m *DBModel) BrokenLinks() ([]BrokenLink, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select t.kind, t.id, t.name, lc.url, lc.status, lc.final_url, lc.redirects, lc.error, lc.checked_at
+	from (` + linkTargetsQuery + `) as t (kind, id, name, url)
+	join link_check as lc on lc.url = t.url
+	where lc.error <> '' or lc.status >= 400
+	order by t.kind, t.id`
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []BrokenLink
+	for rows.Next() {
+		var bl BrokenLink
+		var redirects []byte
+		err := rows.Scan(
+			&bl.Kind,
+			&bl.ID,
+			&bl.Name,
+			&bl.LinkStatus.URL,
+			&bl.LinkStatus.Status,
+			&bl.LinkStatus.FinalURL,
+			&redirects,
+			&bl.LinkStatus.Error,
+			&bl.LinkStatus.CheckedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		json.Unmarshal(redirects, &bl.LinkStatus.Redirects)
+		bl.URL = bl.LinkStatus.URL
+		bl.LinkStatus.Broken = true
+		links = append(links, bl)
+	}
+
+	return links, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLinkStatus(row scanner) (*LinkStatus, error) {
+	var ls LinkStatus
+	var redirects []byte
+
+	err := row.Scan(&ls.URL, &ls.Status, &ls.FinalURL, &redirects, &ls.Error, &ls.CheckedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(redirects, &ls.Redirects)
+	if err != nil {
+		return nil, err
+	}
+
+	ls.Broken = ls.Error != "" || ls.Status >= 400
+	return &ls, nil
+}
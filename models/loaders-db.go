@@ -0,0 +1,285 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// The batch queries below load the relations of many rows at once, so nested
+// GraphQL selections cost one query per relation instead of one per row.
+
+const courseColumns = `c.id, c.university_id, c.course_type, c.name_en, c.name_en_short, c.tuition_fees, c.beginning, c.subject, c.daadlink, c.is_elearning, c.application_deadline,
+	c.is_complete_online_possible, c.programme_duration, c.is_from_daad, c.created_at, COALESCE(c.updated_at, c.created_at),
+	u.name_en, u.name_ch, u.city, u.is_tu9, u.is_u15, COALESCE(u.qs_ranking, 0), u.link`
+
+const universityColumns = `id, name_en, name_ch, city, is_from_daad, is_tu9, is_u15, COALESCE(qs_ranking, 0), link`
+
+// CoursesByIDs returns the courses with the given ids, without languages and articles
+func (m *DBModel) CoursesByIDs(ids []int) (map[int]*Course, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select ` + courseColumns + `
+	from course as c
+	left join university as u on c.university_id = u.id
+	where c.id = any($1)`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	courses := make(map[int]*Course, len(ids))
+	for rows.Next() {
+		course, err := ScanCourse(rows)
+		if err != nil {
+			return nil, err
+		}
+		courses[course.ID] = &course
+	}
+
+	return courses, rows.Err()
+}
+
+// CoursesByUniversityIDs returns the courses of each university
+func (m *DBModel) CoursesByUniversityIDs(ids []int) (map[int][]*Course, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select ` + courseColumns + `
+	from course as c
+	left join university as u on c.university_id = u.id
+	where u.id = any($1)
+	order by c.course_type, c.name_en`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	courses := make(map[int][]*Course, len(ids))
+	for rows.Next() {
+		course, err := ScanCourse(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		universityID, _ := strconv.Atoi(course.UniversityId)
+		courses[universityID] = append(courses[universityID], &course)
+	}
+
+	return courses, rows.Err()
+}
+
+// Universities returns every university ordered by name
+func (m *DBModel) Universities() ([]*University, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select `+universityColumns+` from university order by name_en`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var universities []*University
+	for rows.Next() {
+		u, err := scanUniversity(rows)
+		if err != nil {
+			return nil, err
+		}
+		universities = append(universities, u)
+	}
+
+	return universities, rows.Err()
+}
+
+// UniversitiesByIDs returns the universities with the given ids
+func (m *DBModel) UniversitiesByIDs(ids []int) (map[int]*University, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select `+universityColumns+` from university where id = any($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	universities := make(map[int]*University, len(ids))
+	for rows.Next() {
+		u, err := scanUniversity(rows)
+		if err != nil {
+			return nil, err
+		}
+		universities[u.ID] = u
+	}
+
+	return universities, rows.Err()
+}
+
+// Languages returns every course language
+func (m *DBModel) Languages() ([]Language, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select id, name from language order by name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var languages []Language
+	for rows.Next() {
+		var l Language
+		err := rows.Scan(&l.ID, &l.LanguageName)
+		if err != nil {
+			return nil, err
+		}
+		languages = append(languages, l)
+	}
+
+	return languages, rows.Err()
+}
+
+// LanguagesByCourseIDs returns the languages of each course
+func (m *DBModel) LanguagesByCourseIDs(ids []int) (map[int][]Language, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select cl.course_id, l.id, l.name
+		from courses_languages as cl
+		join language as l on l.id = cl.language_id
+		where cl.course_id = any($1)
+		order by l.name`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := make(map[int][]Language, len(ids))
+	for rows.Next() {
+		var courseID int
+		var l Language
+		err := rows.Scan(&courseID, &l.ID, &l.LanguageName)
+		if err != nil {
+			return nil, err
+		}
+		languages[courseID] = append(languages[courseID], l)
+	}
+
+	return languages, rows.Err()
+}
+
+// ArticlesByIDs returns the articles with the given ids, without their courses
+func (m *DBModel) ArticlesByIDs(ids []int) (map[int]*Article, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := articleQuery(ArticleParams{}) + ` where c.id = any($1)`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	articles := make(map[int]*Article, len(ids))
+	for rows.Next() {
+		article, err := ScanArticles(rows)
+		if err != nil {
+			return nil, err
+		}
+		articles[article.ID] = &article
+	}
+
+	return articles, rows.Err()
+}
+
+// ArticlesByCourseIDs returns the results reported for each course, newest
+// article first. Only the ids of the articles are set.
+func (m *DBModel) ArticlesByCourseIDs(ids []int) (map[int][]CourseArticle, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select a.id, a.course_id, a.result, a.is_decision
+		from article as a
+		left join content as ct on ct.id = a.id
+		where a.course_id = any($1)
+		order by ct.published_date desc`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make(map[int][]CourseArticle, len(ids))
+	for rows.Next() {
+		var ca CourseArticle
+		err := rows.Scan(&ca.ArticleID, &ca.CourseID, &ca.Result, &ca.IsDecision)
+		if err != nil {
+			return nil, err
+		}
+		ca.Article.ID = ca.ArticleID
+		results[ca.CourseID] = append(results[ca.CourseID], ca)
+	}
+
+	return results, rows.Err()
+}
+
+// CoursesByArticleIDs returns the course results reported by each article,
+// admissions first. Only the ids of the courses are set.
+func (m *DBModel) CoursesByArticleIDs(ids []int) (map[int][]ArticleCourse, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select a.id, a.course_id, a.result, a.is_decision
+		from article as a
+		where a.id = any($1)
+		order by array_position(array['Admission','Rejection'], a.result), a.is_decision desc`
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make(map[int][]ArticleCourse, len(ids))
+	for rows.Next() {
+		var ac ArticleCourse
+		err := rows.Scan(&ac.ArticleID, &ac.CourseID, &ac.Result, &ac.IsDecision)
+		if err != nil {
+			return nil, err
+		}
+		ac.Course.ID = ac.CourseID
+		results[ac.ArticleID] = append(results[ac.ArticleID], ac)
+	}
+
+	return results, rows.Err()
+}
+
+func scanUniversity(row scanner) (*University, error) {
+	var u University
+	err := row.Scan(
+		&u.ID,
+		&u.NameEn,
+		&u.NameCh,
+		&u.City,
+		&u.IsFromDaad,
+		&u.IsTu9,
+		&u.IsU15,
+		&u.QsRanking,
+		&u.Link,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
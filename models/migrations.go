@@ -0,0 +1,34 @@
+package models
+
+import (
+	"embed"
+	"strconv"
+	"strings"
+)
+
+// migrations are the schema changes the code expects to be applied
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// LatestMigration returns the highest version of the embedded migrations,
+// taken from the NNNN_ prefix of their file names
+func LatestMigration() (int, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	latest := 0
+	for _, e := range entries {
+		prefix, _, _ := strings.Cut(e.Name(), "_")
+		v, err := strconv.Atoi(prefix)
+		if err != nil {
+			return 0, err
+		}
+		if v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,12 @@
+-- Tracks which files of models/migrations have been applied. Every migration
+-- records its own version as the last statement of its transaction.
+begin;
+
+create table if not exists schema_migrations (
+	version integer primary key,
+	applied_at timestamp not null default now()
+);
+
+insert into schema_migrations (version) values (1);
+
+commit;
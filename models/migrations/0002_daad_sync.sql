@@ -0,0 +1,29 @@
+begin;
+
+create table daad_sync (
+	id serial primary key,
+	snapshot text not null,
+	status text not null default 'pending',
+	diff jsonb not null,
+	created_at timestamp not null default now(),
+	reviewed_at timestamp,
+	reviewed_by integer
+);
+
+create table daad_sync_change (
+	id serial primary key,
+	sync_id integer not null references daad_sync (id),
+	daad_id integer not null,
+	course_id integer not null,
+	change text not null,
+	field text not null default '',
+	old_value text not null default '',
+	new_value text not null default '',
+	changed_at timestamp not null default now()
+);
+
+create index daad_sync_change_course_id_idx on daad_sync_change (course_id);
+
+insert into schema_migrations (version) values (2);
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+create table link_check (
+	url text primary key,
+	status integer not null default 0,
+	final_url text not null default '',
+	redirects jsonb not null default '[]',
+	error text not null default '',
+	checked_at timestamp not null
+);
+
+insert into schema_migrations (version) values (3);
+
+commit;
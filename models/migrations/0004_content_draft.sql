@@ -0,0 +1,21 @@
+begin;
+
+create table content_draft (
+	id serial primary key,
+	source text not null,
+	link text not null,
+	canonical_link text not null unique,
+	title text not null default '',
+	author text not null default '',
+	published_at timestamp,
+	content text not null default '',
+	status text not null default 'pending',
+	content_id integer,
+	created_at timestamp not null default now(),
+	reviewed_at timestamp,
+	reviewed_by integer
+);
+
+insert into schema_migrations (version) values (4);
+
+commit;
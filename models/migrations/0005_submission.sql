@@ -0,0 +1,22 @@
+begin;
+
+create table submission (
+	id serial primary key,
+	content jsonb not null,
+	results jsonb not null default '[]',
+	email text not null default '',
+	remote_addr text not null default '',
+	status text not null default 'pending',
+	reviewer_note text not null default '',
+	content_id integer,
+	created_at timestamp not null default now(),
+	updated_at timestamp not null default now(),
+	reviewed_at timestamp,
+	reviewed_by integer
+);
+
+create index submission_status_idx on submission (status, created_at);
+
+insert into schema_migrations (version) values (5);
+
+commit;
@@ -0,0 +1,25 @@
+begin;
+
+alter table gogermany_user
+	add column email_verified_at timestamp,
+	add column is_admin boolean not null default false,
+	add column created_at timestamp not null default now();
+
+-- accounts created by hand before registration existed are the admins
+update gogermany_user set is_admin = true, email_verified_at = now();
+
+create unique index gogermany_user_email_idx on gogermany_user (lower(email));
+
+create table user_token (
+	hash bytea primary key,
+	user_id integer not null references gogermany_user (id) on delete cascade,
+	scope text not null,
+	expires_at timestamp not null,
+	used_at timestamp
+);
+
+create index user_token_user_idx on user_token (user_id, scope);
+
+insert into schema_migrations (version) values (6);
+
+commit;
@@ -0,0 +1,16 @@
+begin;
+
+create table user_role (
+	user_id integer not null references gogermany_user (id) on delete cascade,
+	role text not null,
+	primary key (user_id, role)
+);
+
+insert into user_role (user_id, role)
+select id, case when is_admin then 'admin' else 'reader' end from gogermany_user;
+
+alter table gogermany_user drop column is_admin;
+
+insert into schema_migrations (version) values (7);
+
+commit;
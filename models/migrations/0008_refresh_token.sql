@@ -0,0 +1,23 @@
+begin;
+
+create table refresh_token (
+	hash bytea primary key,
+	user_id integer not null references gogermany_user (id) on delete cascade,
+	family text not null,
+	created_at timestamp not null default now(),
+	expires_at timestamp not null,
+	used_at timestamp,
+	revoked_at timestamp
+);
+
+create index refresh_token_family_idx on refresh_token (family);
+create index refresh_token_user_idx on refresh_token (user_id);
+
+create table revoked_jti (
+	jti text primary key,
+	expires_at timestamp not null
+);
+
+insert into schema_migrations (version) values (8);
+
+commit;
@@ -0,0 +1,28 @@
+begin;
+
+create table api_key (
+	id serial primary key,
+	name text not null,
+	owner_email text not null default '',
+	prefix text not null,
+	hash bytea not null unique,
+	rate_per_minute integer not null,
+	burst integer not null,
+	daily_quota integer not null,
+	status text not null default 'active',
+	created_at timestamp not null default now(),
+	created_by integer,
+	last_used_at timestamp
+);
+
+create table api_key_usage (
+	api_key_id integer not null references api_key (id) on delete cascade,
+	day date not null,
+	endpoint text not null,
+	count integer not null default 0,
+	primary key (api_key_id, day, endpoint)
+);
+
+insert into schema_migrations (version) values (9);
+
+commit;
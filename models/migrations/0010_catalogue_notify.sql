@@ -0,0 +1,26 @@
+-- Notifies the catalogue_changes channel with the table name after every
+-- write to the catalogue, so each API instance can drop its cached copies.
+begin;
+
+create or replace function notify_catalogue_change() returns trigger as $$
+begin
+	perform pg_notify('catalogue_changes', tg_table_name);
+	return null;
+end;
+$$ language plpgsql;
+
+create trigger course_notify after insert or update or delete or truncate on course
+	for each statement execute procedure notify_catalogue_change();
+
+create trigger university_notify after insert or update or delete or truncate on university
+	for each statement execute procedure notify_catalogue_change();
+
+create trigger content_notify after insert or update or delete or truncate on content
+	for each statement execute procedure notify_catalogue_change();
+
+create trigger article_notify after insert or update or delete or truncate on article
+	for each statement execute procedure notify_catalogue_change();
+
+insert into schema_migrations (version) values (10);
+
+commit;
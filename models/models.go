@@ -46,10 +46,11 @@ type Course struct {
 	UniversityLink           string    `json:"university_link"`
 	// CourseLanguage           map[int]string `json:"languages"`
 	// CourseArticle            map[int]Article `json:"articles"`
-	CourseLanguage []string  `json:"languages"`
-	CourseArticle  []Article `json:"articles"`
-	Languages      string    `json:"-"`
-	ArticleCount   int       `json:"-"`
+	CourseLanguage []string    `json:"languages"`
+	CourseArticle  []Article   `json:"articles"`
+	Languages      string      `json:"-"`
+	ArticleCount   int         `json:"-"`
+	LinkStatus     *LinkStatus `json:"link_status,omitempty"`
 }
 
 // Language is the type for languages
@@ -93,6 +94,7 @@ type Article struct {
 	IsDecision          bool            `json:"is_decision"`
 	ArticleCourse       []ArticleCourse `json:"courses"`
 	Content             string          `json:"content"`
+	LinkStatus          *LinkStatus     `json:"link_status,omitempty"`
 }
 
 // CourseArticle is the type for course article
@@ -158,9 +160,21 @@ type ArticleFilters struct {
 
 // User is the type for users
 type User struct {
-	ID       int
-	Email    string
-	Password string
+	ID              int
+	Email           string
+	Password        string
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	Roles           []string
+}
+
+// UserAccount is the type for users listed in user management
+type UserAccount struct {
+	ID              int        `json:"id"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Roles           []string   `json:"roles"`
 }
 
 // University is the type for universities
@@ -203,3 +217,193 @@ type Content struct {
 	CourseType          string    `json:"course_type"`
 	Content             string    `json:"Content"`
 }
+
+// CourseComparison is the type for side-by-side course comparison
+type CourseComparison struct {
+	Courses    []*Course                `json:"courses"`
+	Attributes []ComparedAttribute      `json:"attributes"`
+	Statistics []CourseAdmissionSummary `json:"statistics"`
+}
+
+// ComparedAttribute is one row of the comparison matrix, values are in the same order as the courses
+type ComparedAttribute struct {
+	Name    string        `json:"name"`
+	Values  []interface{} `json:"values"`
+	Differs bool          `json:"differs"`
+}
+
+// CourseAdmissionSummary is the type for admission outcomes reported for a course
+type CourseAdmissionSummary struct {
+	CourseID      int     `json:"course_id"`
+	ArticleCount  int     `json:"article_count"`
+	Admissions    int     `json:"admissions"`
+	Rejections    int     `json:"rejections"`
+	Decisions     int     `json:"decisions"`
+	AdmissionRate float64 `json:"admission_rate"`
+}
+
+// DaadSync is the type for a DAAD snapshot diff waiting for or after review
+type DaadSync struct {
+	ID         int          `json:"id"`
+	Snapshot   string       `json:"snapshot"`
+	Status     string       `json:"status"`
+	Diff       DaadDiff     `json:"diff"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ReviewedAt *time.Time   `json:"reviewed_at"`
+	ReviewedBy *int         `json:"reviewed_by"`
+	Changes    []DaadChange `json:"changes,omitempty"`
+}
+
+// DaadDiff is the difference between a DAAD snapshot and the course table.
+// Unmatched programmes are not applied.
+type DaadDiff struct {
+	New       []DaadProgramme      `json:"new"`
+	Changed   []DaadChangedCourse  `json:"changed"`
+	Vanished  []DaadVanishedCourse `json:"vanished"`
+	Unmatched []DaadProgramme      `json:"unmatched"`
+}
+
+// DaadProgramme is the type for one programme of a DAAD snapshot
+type DaadProgramme struct {
+	DaadID                   int      `json:"daad_id"`
+	UniversityID             int      `json:"university_id"`
+	University               string   `json:"university"`
+	City                     string   `json:"city"`
+	CourseType               string   `json:"course_type"`
+	NameEn                   string   `json:"name_en"`
+	NameEnShort              string   `json:"name_en_short"`
+	Subject                  string   `json:"subject"`
+	Languages                []string `json:"languages"`
+	TuitionFees              string   `json:"tuition_fees"`
+	Beginning                string   `json:"beginning"`
+	ProgrammeDuration        string   `json:"programme_duration"`
+	ApplicationDeadline      string   `json:"application_deadline"`
+	IsElearning              *bool    `json:"is_elearning,omitempty"`
+	IsCompleteOnlinePossible *bool    `json:"is_complete_online_possible,omitempty"`
+	Daadlink                 string   `json:"daadlink"`
+}
+
+// DaadChangedCourse is the type for a course whose DAAD programme changed
+type DaadChangedCourse struct {
+	DaadID   int               `json:"daad_id"`
+	CourseID int               `json:"course_id"`
+	NameEn   string            `json:"name_en"`
+	Changes  []DaadFieldChange `json:"changes"`
+}
+
+// DaadFieldChange is one changed course column
+type DaadFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// DaadVanishedCourse is the type for a course no longer in the DAAD snapshot
+type DaadVanishedCourse struct {
+	DaadID   int    `json:"daad_id"`
+	CourseID int    `json:"course_id"`
+	NameEn   string `json:"name_en"`
+}
+
+// DaadChange is the type for a recorded change of a DAAD sync: new, changed,
+// vanished, or conflict when the course was edited after the diff was computed
+// and the change was skipped. OldValue of a conflict is the current value.
+type DaadChange struct {
+	ID        int       `json:"id"`
+	SyncID    int       `json:"sync_id"`
+	DaadID    int       `json:"daad_id"`
+	CourseID  int       `json:"course_id"`
+	Change    string    `json:"change"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// LinkStatus is the type for the last check of an external link
+type LinkStatus struct {
+	URL       string    `json:"url"`
+	Status    int       `json:"status"`
+	FinalURL  string    `json:"final_url"`
+	Redirects []string  `json:"redirects"`
+	Error     string    `json:"error"`
+	Broken    bool      `json:"broken"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// LinkTarget is a row that stores an external link
+type LinkTarget struct {
+	Kind string `json:"kind"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// BrokenLink is the type for a link target whose last check failed
+type BrokenLink struct {
+	LinkTarget
+	LinkStatus LinkStatus `json:"link_status"`
+}
+
+// ContentDraft is the type for an ingested article waiting for review
+type ContentDraft struct {
+	ID            int        `json:"id"`
+	Source        string     `json:"source"`
+	Link          string     `json:"link"`
+	CanonicalLink string     `json:"canonical_link"`
+	Title         string     `json:"title"`
+	Author        string     `json:"author"`
+	PublishedAt   *time.Time `json:"published_at"`
+	Content       string     `json:"content"`
+	Status        string     `json:"status"`
+	ContentID     *int       `json:"content_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewedBy    *int       `json:"reviewed_by"`
+}
+
+// Submission is the type for an article and its results sent in by a reader
+type Submission struct {
+	ID           int                `json:"id"`
+	Content      Content            `json:"content"`
+	Results      []SubmissionResult `json:"results"`
+	Email        string             `json:"email"`
+	RemoteAddr   string             `json:"remote_addr"`
+	Status       string             `json:"status"`
+	ReviewerNote string             `json:"reviewer_note"`
+	ContentID    *int               `json:"content_id"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	ReviewedAt   *time.Time         `json:"reviewed_at"`
+	ReviewedBy   *int               `json:"reviewed_by"`
+}
+
+// SubmissionResult is the type for one course result in a submission
+type SubmissionResult struct {
+	CourseID   int    `json:"course_id"`
+	Result     string `json:"result"`
+	IsDecision bool   `json:"is_decision"`
+}
+
+// APIKey is the type for keys issued to third party consumers
+type APIKey struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	OwnerEmail    string        `json:"owner_email"`
+	Prefix        string        `json:"prefix"`
+	RatePerMinute int           `json:"rate_per_minute"`
+	Burst         int           `json:"burst"`
+	DailyQuota    int           `json:"daily_quota"`
+	Status        string        `json:"status"`
+	CreatedAt     time.Time     `json:"created_at"`
+	CreatedBy     *int          `json:"created_by"`
+	LastUsedAt    *time.Time    `json:"last_used_at"`
+	Usage         []APIKeyUsage `json:"usage,omitempty"`
+}
+
+// APIKeyUsage is the type for the requests of a key to one endpoint on one day
+type APIKeyUsage struct {
+	Day      string `json:"day"`
+	Endpoint string `json:"endpoint"`
+	Count    int    `json:"count"`
+}
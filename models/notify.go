@@ -0,0 +1,55 @@
+package models
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ChangesChannel is notified by the triggers of migration 0010 with the name
+// of the catalogue table that was written
+const ChangesChannel = "catalogue_changes"
+
+// ListenChanges calls onChange with the table of every notification on
+// ChangesChannel until ctx is done. A dropped connection is reestablished on
+// its own and onChange is then called with an empty table, since changes may
+// have been missed in the meantime.
+func ListenChanges(ctx context.Context, dsn string, logger *slog.Logger, onChange func(table string)) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Warn("listen", "error", err)
+		}
+	})
+	defer listener.Close()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	err := listener.Listen(ChangesChannel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case n, ok := <-listener.Notify:
+			if !ok {
+				return ctx.Err()
+			}
+			if n == nil {
+				// reconnected
+				onChange("")
+				continue
+			}
+			onChange(n.Extra)
+		case <-time.After(90 * time.Second):
+			go listener.Ping()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const submissionColumns = `id, content, results, email, remote_addr, status, reviewer_note, content_id,
+	created_at, updated_at, reviewed_at, reviewed_by`
+
+// InsertSubmission stores a reader submission as pending and returns its id
+func (m *DBModel) InsertSubmission(s Submission) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	content, results, err := marshalSubmission(s)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := `insert into submission (content, results, email, remote_addr, status, created_at, updated_at)
+	values ($1, $2, $3, $4, 'pending', $5, $5) returning id`
+
+	var id int
+	err = m.DB.QueryRowContext(ctx, stmt, content, results, s.Email, s.RemoteAddr, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetSubmissions returns the submissions with the given status, all submissions if status is empty
+func (m *DBModel) GetSubmissions(status string) ([]*Submission, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	query := `select ` + submissionColumns + ` from submission where $1 = '' or status = $1 order by created_at`
+
+	rows, err := m.DB.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var submissions []*Submission
+	for rows.Next() {
+		s, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, s)
+	}
+
+	return submissions, rows.Err()
+}
+
+// GetSubmission returns one submission
+func (m *DBModel) GetSubmission(id int) (*Submission, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, `select `+submissionColumns+` from submission where id = $1`, id)
+
+	return scanSubmission(row)
+}
+
+// UpdateSubmission replaces the content, results and reviewer note of a pending submission
+func (m *DBModel) UpdateSubmission(s Submission) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	content, results, err := marshalSubmission(s)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockPendingSubmission(ctx, tx, s.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `update submission set content = $2, results = $3, reviewer_note = $4, updated_at = $5 where id = $1`,
+		s.ID, content, results, s.ReviewerNote, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ApproveSubmission inserts the content and article rows of a pending
+// submission and marks it approved in one transaction. A zero content id
+// takes the next free id.
+func (m *DBModel) ApproveSubmission(id int, note string, reviewer int) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	err = lockPendingSubmission(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	s, err := scanSubmission(tx.QueryRowContext(ctx, `select `+submissionColumns+` from submission where id = $1`, id))
+	if err != nil {
+		return 0, err
+	}
+
+	content := s.Content
+	if content.ID == 0 {
+		err = tx.QueryRowContext(ctx, `select coalesce(max(id), 0) + 1 from content`).Scan(&content.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = insertContent(ctx, tx, content)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range s.Results {
+		err = insertArticle(ctx, tx, CourseArticle{
+			ArticleID:  content.ID,
+			CourseID:   r.CourseID,
+			Result:     r.Result,
+			IsDecision: r.IsDecision,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if note == "" {
+		note = s.ReviewerNote
+	}
+
+	_, err = tx.ExecContext(ctx, `update submission set status = 'approved', content_id = $2, reviewer_note = $3, reviewed_at = $4, reviewed_by = $5 where id = $1`,
+		id, content.ID, note, time.Now(), reviewer)
+	if err != nil {
+		return 0, err
+	}
+
+	return content.ID, tx.Commit()
+}
+
+// RejectSubmission marks a pending submission rejected, keeping the old note if note is empty
+func (m *DBModel) RejectSubmission(id int, note string, reviewer int) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockPendingSubmission(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `update submission set status = 'rejected', reviewer_note = coalesce(nullif($2, ''), reviewer_note),
+		reviewed_at = $3, reviewed_by = $4 where id = $1`,
+		id, note, time.Now(), reviewer)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func lockPendingSubmission(ctx context.Context, tx *sql.Tx, id int) error {
+	var status string
+
+	err := tx.QueryRowContext(ctx, `select status from submission where id = $1 for update`, id).Scan(&status)
+	if err != nil {
+		return err
+	}
+
+	if status != "pending" {
+		return errors.New("submission is already " + status)
+	}
+	return nil
+}
+
+func marshalSubmission(s Submission) ([]byte, []byte, error) {
+	content, err := json.Marshal(s.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.Results == nil {
+		s.Results = []SubmissionResult{}
+	}
+	results, err := json.Marshal(s.Results)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return content, results, nil
+}
+
+func scanSubmission(row scanner) (*Submission, error) {
+	var s Submission
+	var content, results []byte
+	err := row.Scan(
+		&s.ID,
+		&content,
+		&results,
+		&s.Email,
+		&s.RemoteAddr,
+		&s.Status,
+		&s.ReviewerNote,
+		&s.ContentID,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.ReviewedAt,
+		&s.ReviewedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &s.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(results, &s.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
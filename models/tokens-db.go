@@ -0,0 +1,143 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrRefreshTokenReused is returned when a rotated refresh token is presented
+// again. The whole token family is revoked when this happens.
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected, please sign in again")
+
+// IssueRefreshToken stores a refresh token starting a new family and returns its plaintext
+func (m *DBModel) IssueRefreshToken(userID int, ttl time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	family, _, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	return insertRefreshToken(ctx, m.DB, userID, family, ttl)
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one of the same
+// family and returns the user it belongs to
+func (m *DBModel) RotateRefreshToken(token string, ttl time.Duration) (int, string, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var family string
+	var expiresAt time.Time
+	var usedAt, revokedAt *time.Time
+
+	err = tx.QueryRowContext(ctx, `select user_id, family, expires_at, used_at, revoked_at from refresh_token where hash = $1 for update`,
+		hashToken(token)).Scan(&userID, &family, &expiresAt, &usedAt, &revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", ErrInvalidToken
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	now := time.Now()
+
+	if usedAt != nil && revokedAt == nil {
+		_, err = tx.ExecContext(ctx, `update refresh_token set revoked_at = $2 where family = $1 and revoked_at is null`, family, now)
+		if err != nil {
+			return 0, "", err
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return 0, "", err
+		}
+		return 0, "", ErrRefreshTokenReused
+	}
+
+	if revokedAt != nil || usedAt != nil || !expiresAt.After(now) {
+		return 0, "", ErrInvalidToken
+	}
+
+	_, err = tx.ExecContext(ctx, `update refresh_token set used_at = $2 where hash = $1`, hashToken(token), now)
+	if err != nil {
+		return 0, "", err
+	}
+
+	next, err := insertRefreshToken(ctx, tx, userID, family, ttl)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return userID, next, tx.Commit()
+}
+
+// RevokeRefreshFamily revokes the refresh token and every token rotated from
+// the same sign in. Unknown tokens are ignored.
+func (m *DBModel) RevokeRefreshFamily(token string) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, `update refresh_token set revoked_at = $2
+		where family = (select family from refresh_token where hash = $1) and revoked_at is null`,
+		hashToken(token), time.Now())
+
+	return err
+}
+
+// RevokeJTI denylists an access token id until the token expires
+func (m *DBModel) RevokeJTI(jti string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, `delete from revoked_jti where expires_at < $1`, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = m.DB.ExecContext(ctx, `insert into revoked_jti (jti, expires_at) values ($1, $2) on conflict (jti) do nothing`,
+		jti, expiresAt)
+
+	return err
+}
+
+// IsJTIRevoked reports whether an access token id is denylisted
+func (m *DBModel) IsJTIRevoked(jti string) (bool, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	var revoked bool
+	err := m.DB.QueryRowContext(ctx, `select exists (select 1 from revoked_jti where jti = $1)`, jti).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+func insertRefreshToken(ctx context.Context, db execer, userID int, family string, ttl time.Duration) (string, error) {
+	token, hash, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	_, err = db.ExecContext(ctx, `insert into refresh_token (hash, user_id, family, created_at, expires_at) values ($1, $2, $3, $4, $5)`,
+		hash, userID, family, now, now.Add(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
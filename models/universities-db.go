@@ -2,11 +2,12 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
 func (m *DBModel) InsertUniversity(university University) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
 	defer cancel()
 
 	stmt := `insert into university (id, name_en, name_ch, city, is_from_daad, is_tu9, is_u15, qs_ranking, created_at, updated_at, link) values 
@@ -30,3 +31,65 @@ func (m *DBModel) InsertUniversity(university University) error {
 	}
 	return nil
 }
+
+// UniversityIndex returns the id, names and city of every university
+func (m *DBModel) UniversityIndex() ([]University, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select id, name_en, name_ch, city from university order by id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var universities []University
+	for rows.Next() {
+		var u University
+		err := rows.Scan(&u.ID, &u.NameEn, &u.NameCh, &u.City)
+		if err != nil {
+			return nil, err
+		}
+		universities = append(universities, u)
+	}
+
+	return universities, rows.Err()
+}
+
+// ImportUniversities inserts and updates universities in a single transaction
+func (m *DBModel) ImportUniversities(inserts, updates []University) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 100*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insertStmt := `insert into university (id, name_en, name_ch, city, is_from_daad, is_tu9, is_u15, qs_ranking, created_at, updated_at, link) values 
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	for _, u := range inserts {
+		_, err = tx.ExecContext(ctx, insertStmt,
+			u.ID, u.NameEn, u.NameCh, u.City, u.IsFromDaad, u.IsTu9, u.IsU15, u.QsRanking, u.CreatedAt, nil, u.Link,
+		)
+		if err != nil {
+			return fmt.Errorf("university %d: %w", u.ID, err)
+		}
+	}
+
+	updateStmt := `update university set name_en = $2, name_ch = $3, city = $4, is_from_daad = $5, is_tu9 = $6, is_u15 = $7,
+	qs_ranking = $8, link = $9, updated_at = $10 where id = $1`
+
+	for _, u := range updates {
+		_, err = tx.ExecContext(ctx, updateStmt,
+			u.ID, u.NameEn, u.NameCh, u.City, u.IsFromDaad, u.IsTu9, u.IsU15, u.QsRanking, u.Link, time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("university %d: %w", u.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
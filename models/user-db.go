@@ -2,23 +2,292 @@ package models
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"errors"
 	"time"
+
+	"github.com/lib/pq"
+)
+
+// Token scopes
+const (
+	ScopeVerification  = "verification"
+	ScopePasswordReset = "password_reset"
+)
+
+var (
+	// ErrDuplicateEmail is returned when an account with the email already exists
+	ErrDuplicateEmail = errors.New("an account with this email already exists")
+	// ErrInvalidToken is returned for unknown, used or expired user tokens
+	ErrInvalidToken = errors.New("invalid or expired token")
 )
 
+const userColumns = `id, email, password, email_verified_at, created_at,
+	array(select role from user_role where user_id = gogermany_user.id order by role)`
+
 func (m *DBModel) GetUser(email string) (*User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	query := `select ` + userColumns + ` from gogermany_user where lower(email) = lower($1)`
+
+	return scanUser(m.DB.QueryRowContext(ctx, query, email))
+}
+
+// GetUserByID returns one user
+func (m *DBModel) GetUserByID(id int) (*User, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	query := `select ` + userColumns + ` from gogermany_user where id = $1`
+
+	return scanUser(m.DB.QueryRowContext(ctx, query, id))
+}
+
+// InsertUser creates an unverified account with the reader role and returns its id
+func (m *DBModel) InsertUser(email, passwordHash string) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// ids are not generated by the table, serialize inserts so max + 1 stays unique
+	_, err = tx.ExecContext(ctx, `lock table gogermany_user in share row exclusive mode`)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = tx.QueryRowContext(ctx, `insert into gogermany_user (id, email, password, created_at)
+		values ((select coalesce(max(id), 0) + 1 from gogermany_user), $1, $2, $3) returning id`,
+		email, passwordHash, time.Now()).Scan(&id)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return 0, ErrDuplicateEmail
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `insert into user_role (user_id, role) values ($1, 'reader')`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// GetUsers returns every account with its roles
+func (m *DBModel) GetUsers() ([]*UserAccount, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, `select `+userColumns+` from gogermany_user order by id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*UserAccount
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &UserAccount{
+			ID:              u.ID,
+			Email:           u.Email,
+			EmailVerifiedAt: u.EmailVerifiedAt,
+			CreatedAt:       u.CreatedAt,
+			Roles:           u.Roles,
+		})
+	}
+
+	return users, rows.Err()
+}
+
+// SetUserRoles replaces the roles of a user
+func (m *DBModel) SetUserRoles(id int, roles []string) error {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `select true from gogermany_user where id = $1 for update`, id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from user_role where user_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `insert into user_role (user_id, role) select $1, unnest($2::text[])`, id, pq.Array(roles))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// NewUserToken stores a single use token for the user and returns its plaintext.
+// Only the sha256 hash is kept in the database.
+func (m *DBModel) NewUserToken(userID int, scope string, ttl time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	token, hash, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = m.DB.ExecContext(ctx, `insert into user_token (hash, user_id, scope, expires_at) values ($1, $2, $3, $4)`,
+		hash, userID, scope, time.Now().Add(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// GetUserByToken returns the user of an unused and unexpired token without
+// consuming it
+func (m *DBModel) GetUserByToken(token, scope string) (*User, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	query := `select ` + userColumns + ` from gogermany_user where id = (
+		select user_id from user_token where hash = $1 and scope = $2 and used_at is null and expires_at > $3)`
+
+	user, err := scanUser(m.DB.QueryRowContext(ctx, query, hashToken(token), scope, time.Now()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	return user, err
+}
+
+// VerifyEmail consumes a verification token and marks the email of its user verified
+func (m *DBModel) VerifyEmail(token string) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
 	defer cancel()
 
-	query := `select id, email, password from gogermany_user where email = $1`
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	userID, err := consumeUserToken(ctx, tx, token, ScopeVerification)
+	if err != nil {
+		return 0, err
+	}
 
-	row := m.DB.QueryRowContext(ctx, query, email)
+	_, err = tx.ExecContext(ctx, `update gogermany_user set email_verified_at = coalesce(email_verified_at, $2) where id = $1`,
+		userID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, tx.Commit()
+}
+
+// ResetPassword consumes a password reset token, sets the new password hash
+// and invalidates the other reset tokens and the refresh tokens of the user.
+// The email counts as verified since the token was delivered to it.
+func (m *DBModel) ResetPassword(token, passwordHash string) (int, error) {
+	ctx, cancel := context.WithTimeout(m.baseContext(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	userID, err := consumeUserToken(ctx, tx, token, ScopePasswordReset)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	_, err = tx.ExecContext(ctx, `update gogermany_user set password = $2, email_verified_at = coalesce(email_verified_at, $3) where id = $1`,
+		userID, passwordHash, now)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `update user_token set used_at = $3 where user_id = $1 and scope = $2 and used_at is null`,
+		userID, ScopePasswordReset, now)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `update refresh_token set revoked_at = $2 where user_id = $1 and revoked_at is null`, userID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, tx.Commit()
+}
+
+func consumeUserToken(ctx context.Context, tx *sql.Tx, token, scope string) (int, error) {
+	var userID int
+	err := tx.QueryRowContext(ctx, `update user_token set used_at = $3
+		where hash = $1 and scope = $2 and used_at is null and expires_at > $3
+		returning user_id`,
+		hashToken(token), scope, time.Now()).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrInvalidToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// randomToken returns a random url safe token and its sha256 hash
+func randomToken() (string, []byte, error) {
+	b := make([]byte, 20)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
 
+func scanUser(row scanner) (*User, error) {
 	var user User
 
 	err := row.Scan(
 		&user.ID,
 		&user.Email,
 		&user.Password,
+		&user.EmailVerifiedAt,
+		&user.CreatedAt,
+		pq.Array(&user.Roles),
 	)
 	if err != nil {
 		return nil, err